@@ -0,0 +1,237 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AttachmentHandler struct {
+	attachmentUsecase domain.AttachmentUsecase
+}
+
+// NewAttachmentHandler initializes the attachment handler
+func NewAttachmentHandler(e *echo.Echo, usecase domain.AttachmentUsecase) {
+	handler := &AttachmentHandler{
+		attachmentUsecase: usecase,
+	}
+
+	e.POST("/todos/:id/attachments", handler.Upload)
+	e.GET("/todos/:id/attachments", handler.GetAll)
+	e.GET("/todos/:id/attachments/:attachment_id", handler.GetByID)
+	e.GET("/todos/:id/attachments/:attachment_id/content", handler.Download)
+	e.DELETE("/todos/:id/attachments/:attachment_id", handler.Delete)
+}
+
+// Upload godoc
+// @Summary      Attach a file to a todo
+// @Description  Upload a file as multipart/form-data in the "file" field. The file is streamed to storage, not buffered.
+// @Tags         attachments
+// @Accept       mpfd
+// @Produce      json
+// @Param        id    path      int   true  "Todo ID"
+// @Param        file  formData  file  true  "File to attach"
+// @Success      201   {object}  domain.Attachment
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      413   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /todos/{id}/attachments [post]
+func (h *AttachmentHandler) Upload(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Missing file",
+			})
+		}
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		attachment := &domain.Attachment{
+			TodoID:      uint(todoID),
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get(echo.HeaderContentType),
+		}
+		err = h.attachmentUsecase.Upload(attachment, part)
+		part.Close()
+		if err != nil {
+			return c.JSON(errorStatus(err), map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(http.StatusCreated, attachment)
+	}
+}
+
+// GetAll godoc
+// @Summary      List the attachments of a todo
+// @Description  Get the metadata of all files attached to a todo
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {array}   domain.Attachment
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/attachments [get]
+func (h *AttachmentHandler) GetAll(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	attachments, err := h.attachmentUsecase.GetByTodoID(uint(todoID))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, attachments)
+}
+
+// GetByID godoc
+// @Summary      Get an attachment by ID
+// @Description  Get the metadata of a single attachment
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Param        id             path      int  true  "Todo ID"
+// @Param        attachment_id  path      int  true  "Attachment ID"
+// @Success      200  {object}  domain.Attachment
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandler) GetByID(c echo.Context) error {
+	todoID, id, err := parseAttachmentPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	attachment, err := h.attachmentUsecase.GetByID(todoID, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, attachment)
+}
+
+// Download godoc
+// @Summary      Download an attachment
+// @Description  Stream the content of an attachment
+// @Tags         attachments
+// @Produce      octet-stream
+// @Param        id             path      int  true  "Todo ID"
+// @Param        attachment_id  path      int  true  "Attachment ID"
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/attachments/{attachment_id}/content [get]
+func (h *AttachmentHandler) Download(c echo.Context) error {
+	todoID, id, err := parseAttachmentPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	attachment, content, err := h.attachmentUsecase.Open(todoID, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.Filename,
+	}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	header.Set("ETag", strconv.Quote(attachment.Checksum))
+	// The content type comes from the uploader, so browsers must not
+	// guess another one, e.g. HTML, from the content.
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	return c.Stream(http.StatusOK, attachment.ContentType, content)
+}
+
+// Delete godoc
+// @Summary      Delete an attachment
+// @Description  Remove a file from a todo
+// @Tags         attachments
+// @Accept       json
+// @Produce      json
+// @Param        id             path      int  true  "Todo ID"
+// @Param        attachment_id  path      int  true  "Attachment ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) Delete(c echo.Context) error {
+	todoID, id, err := parseAttachmentPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if err := h.attachmentUsecase.Delete(todoID, id); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseAttachmentPath reads the todo and attachment IDs from the request path.
+func parseAttachmentPath(c echo.Context) (todoID, id uint, err error) {
+	t, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	i, err := strconv.ParseUint(c.Param("attachment_id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint(t), uint(i), nil
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ChecklistHandler struct {
+	todoUsecase domain.TodoUsecase
+}
+
+// NewChecklistHandler initializes the handler for the checklist of a todo
+func NewChecklistHandler(e *echo.Echo, usecase domain.TodoUsecase) {
+	handler := &ChecklistHandler{
+		todoUsecase: usecase,
+	}
+
+	e.POST("/todos/:id/checklist", handler.Create)
+	e.PUT("/todos/:id/checklist/order", handler.Reorder)
+	e.POST("/todos/:id/checklist/:item_id/toggle", handler.Toggle)
+	e.DELETE("/todos/:id/checklist/:item_id", handler.Delete)
+}
+
+// Create godoc
+// @Summary      Add a checklist item
+// @Description  Append an item to the checklist of a todo
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                   true  "Todo ID"
+// @Param        item  body      domain.ChecklistItem  true  "Checklist item"
+// @Success      201   {object}  domain.Todo
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /todos/{id}/checklist [post]
+func (h *ChecklistHandler) Create(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	item := new(domain.ChecklistItem)
+	if err := c.Bind(item); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	item.Checked = false
+	todo, err := h.todoUsecase.AddChecklistItem(uint(todoID), item)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, todo)
+}
+
+// Reorder godoc
+// @Summary      Reorder a checklist
+// @Description  Put the checklist items of a todo in the given order. item_ids must list every item exactly once.
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                    true  "Todo ID"
+// @Param        order  body      domain.ChecklistOrder  true  "New order of the items"
+// @Success      200    {object}  domain.Todo
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /todos/{id}/checklist/order [put]
+func (h *ChecklistHandler) Reorder(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	var order domain.ChecklistOrder
+	if err := c.Bind(&order); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	todo, err := h.todoUsecase.ReorderChecklist(uint(todoID), order)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Toggle godoc
+// @Summary      Check or uncheck a checklist item
+// @Description  Flip the checked flag of a checklist item. Checking the last open item completes the todo when checklist_auto_complete is set and the todo is not blocked.
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Todo ID"
+// @Param        item_id  path      int  true  "Checklist item ID"
+// @Success      200      {object}  domain.Todo
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /todos/{id}/checklist/{item_id}/toggle [post]
+func (h *ChecklistHandler) Toggle(c echo.Context) error {
+	todoID, itemID, err := parseChecklistPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	todo, err := h.todoUsecase.ToggleChecklistItem(todoID, itemID)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Delete godoc
+// @Summary      Remove a checklist item
+// @Description  Remove an item from the checklist of a todo
+// @Tags         checklist
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Todo ID"
+// @Param        item_id  path      int  true  "Checklist item ID"
+// @Success      200      {object}  domain.Todo
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /todos/{id}/checklist/{item_id} [delete]
+func (h *ChecklistHandler) Delete(c echo.Context) error {
+	todoID, itemID, err := parseChecklistPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	todo, err := h.todoUsecase.RemoveChecklistItem(todoID, itemID)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// parseChecklistPath reads the todo and checklist item IDs from the request path.
+func parseChecklistPath(c echo.Context) (todoID, itemID uint, err error) {
+	t, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	i, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint(t), uint(i), nil
+}
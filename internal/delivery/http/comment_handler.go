@@ -0,0 +1,210 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CommentHandler struct {
+	commentUsecase domain.CommentUsecase
+}
+
+// NewCommentHandler initializes the comment handler
+func NewCommentHandler(e *echo.Echo, usecase domain.CommentUsecase) {
+	handler := &CommentHandler{
+		commentUsecase: usecase,
+	}
+
+	e.POST("/todos/:id/comments", handler.Create)
+	e.GET("/todos/:id/comments", handler.GetAll)
+	e.GET("/todos/:id/comments/:comment_id", handler.GetByID)
+	e.PUT("/todos/:id/comments/:comment_id", handler.Update)
+	e.DELETE("/todos/:id/comments/:comment_id", handler.Delete)
+}
+
+// Create godoc
+// @Summary      Comment on a todo
+// @Description  Add a comment to the thread of a todo
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int             true  "Todo ID"
+// @Param        comment  body      domain.Comment  true  "Comment object"
+// @Success      201      {object}  domain.Comment
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /todos/{id}/comments [post]
+func (h *CommentHandler) Create(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	comment := new(domain.Comment)
+	if err := c.Bind(comment); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	comment.ID = 0
+	comment.TodoID = uint(todoID)
+	if err := h.commentUsecase.Create(comment); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, comment)
+}
+
+// GetAll godoc
+// @Summary      List the comments of a todo
+// @Description  Get the comment thread of a todo, oldest first
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {array}   domain.Comment
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/comments [get]
+func (h *CommentHandler) GetAll(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	comments, err := h.commentUsecase.GetByTodoID(uint(todoID))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, comments)
+}
+
+// GetByID godoc
+// @Summary      Get a comment by ID
+// @Description  Get a single comment of a todo
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Todo ID"
+// @Param        comment_id  path      int  true  "Comment ID"
+// @Success      200  {object}  domain.Comment
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/comments/{comment_id} [get]
+func (h *CommentHandler) GetByID(c echo.Context) error {
+	todoID, id, err := parseCommentPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	comment, err := h.commentUsecase.GetByID(todoID, id)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, comment)
+}
+
+// Update godoc
+// @Summary      Edit a comment
+// @Description  Change the body of a comment
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int             true  "Todo ID"
+// @Param        comment_id  path      int             true  "Comment ID"
+// @Param        comment     body      domain.Comment  true  "Comment object"
+// @Success      200  {object}  domain.Comment
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/comments/{comment_id} [put]
+func (h *CommentHandler) Update(c echo.Context) error {
+	todoID, id, err := parseCommentPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	comment := new(domain.Comment)
+	if err := c.Bind(comment); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	comment.ID = id
+	comment.TodoID = todoID
+	if err := h.commentUsecase.Update(comment); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, comment)
+}
+
+// Delete godoc
+// @Summary      Delete a comment
+// @Description  Remove a comment from the thread of a todo
+// @Tags         comments
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Todo ID"
+// @Param        comment_id  path      int  true  "Comment ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/comments/{comment_id} [delete]
+func (h *CommentHandler) Delete(c echo.Context) error {
+	todoID, id, err := parseCommentPath(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if err := h.commentUsecase.Delete(todoID, id); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseCommentPath reads the todo and comment IDs from the request path.
+func parseCommentPath(c echo.Context) (todoID, id uint, err error) {
+	t, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	i, err := strconv.ParseUint(c.Param("comment_id"), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint(t), uint(i), nil
+}
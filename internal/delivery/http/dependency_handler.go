@@ -0,0 +1,130 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DependencyHandler struct {
+	todoUsecase domain.TodoUsecase
+}
+
+// NewDependencyHandler initializes the handler for blocked-by dependencies
+func NewDependencyHandler(e *echo.Echo, usecase domain.TodoUsecase) {
+	handler := &DependencyHandler{
+		todoUsecase: usecase,
+	}
+
+	e.POST("/todos/:id/dependencies", handler.Create)
+	e.GET("/todos/:id/dependencies", handler.GetAll)
+	e.DELETE("/todos/:id/dependencies/:blocker_id", handler.Delete)
+}
+
+// Create godoc
+// @Summary      Block a todo by another todo
+// @Description  Record that the todo cannot be completed before blocked_by_id is done. Dependencies that would form a cycle are rejected.
+// @Tags         dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                true  "Todo ID"
+// @Param        dependency  body      domain.Dependency  true  "Dependency object"
+// @Success      201         {object}  domain.Dependency
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Failure      500         {object}  map[string]string
+// @Router       /todos/{id}/dependencies [post]
+func (h *DependencyHandler) Create(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	dependency := new(domain.Dependency)
+	if err := c.Bind(dependency); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	dependency.TodoID = uint(todoID)
+	if err := h.todoUsecase.AddBlocker(dependency); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dependency)
+}
+
+// GetAll godoc
+// @Summary      List the blockers of a todo
+// @Description  Get the todos that directly block a todo, including the ones already completed
+// @Tags         dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {array}   domain.Todo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/dependencies [get]
+func (h *DependencyHandler) GetAll(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	blockers, err := h.todoUsecase.GetBlockers(uint(todoID))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, blockers)
+}
+
+// Delete godoc
+// @Summary      Remove a blocker
+// @Description  Remove the dependency of a todo on a blocking todo
+// @Tags         dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Todo ID"
+// @Param        blocker_id  path      int  true  "ID of the blocking todo"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/dependencies/{blocker_id} [delete]
+func (h *DependencyHandler) Delete(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+	blockerID, err := strconv.ParseUint(c.Param("blocker_id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	dependency := &domain.Dependency{TodoID: uint(todoID), BlockedByID: uint(blockerID)}
+	if err := h.todoUsecase.RemoveBlocker(dependency); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"go-todo-api/internal/domain"
+)
+
+// errorStatus maps usecase errors to the HTTP status returned to clients.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{domain.ErrNotFound, http.StatusNotFound},
+		{fmt.Errorf("loading links: %w", domain.ErrNotFound), http.StatusNotFound},
+		{domain.ErrUnknownLinkedTodo, http.StatusBadRequest},
+		{domain.ErrSelfLink, http.StatusBadRequest},
+		{domain.ErrInvalidTransition, http.StatusConflict},
+		{domain.ErrTooLarge, http.StatusRequestEntityTooLarge},
+		{errors.New("connection refused"), http.StatusInternalServerError},
+		{fmt.Errorf("loading links: %w", errors.New("connection refused")), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := errorStatus(tt.err); got != tt.want {
+			t.Errorf("errorStatus(%q) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
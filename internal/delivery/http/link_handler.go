@@ -0,0 +1,130 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LinkHandler struct {
+	todoUsecase domain.TodoUsecase
+}
+
+// NewLinkHandler initializes the handler for links between todos
+func NewLinkHandler(e *echo.Echo, usecase domain.TodoUsecase) {
+	handler := &LinkHandler{
+		todoUsecase: usecase,
+	}
+
+	e.POST("/todos/:id/links", handler.Create)
+	e.GET("/todos/:id/links", handler.GetAll)
+	e.DELETE("/todos/:id/links/:linked_id", handler.Delete)
+}
+
+// Create godoc
+// @Summary      Link a todo to another todo
+// @Description  Link a todo to todo_id as relates_to, duplicate_of, duplicated_by, follow_up_of or followed_up_by; the other todo gets the inverse link. Marking a todo as a duplicate closes it and points it to the canonical todo.
+// @Tags         links
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int              true  "Todo ID"
+// @Param        link  body      domain.TodoLink  true  "Link object"
+// @Success      201   {object}  domain.Todo
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /todos/{id}/links [post]
+func (h *LinkHandler) Create(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	var link domain.TodoLink
+	if err := c.Bind(&link); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	todo, err := h.todoUsecase.AddLink(uint(todoID), link)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, todo)
+}
+
+// GetAll godoc
+// @Summary      List the links of a todo
+// @Description  Get the todos a todo is linked to
+// @Tags         links
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {array}   domain.TodoLink
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/links [get]
+func (h *LinkHandler) GetAll(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	links, err := h.todoUsecase.GetLinks(uint(todoID))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, links)
+}
+
+// Delete godoc
+// @Summary      Remove a link
+// @Description  Remove the link between two todos from both sides. A closed duplicate stays closed.
+// @Tags         links
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Todo ID"
+// @Param        linked_id  path      int  true  "ID of the linked todo"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/links/{linked_id} [delete]
+func (h *LinkHandler) Delete(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+	linkedID, err := strconv.ParseUint(c.Param("linked_id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if err := h.todoUsecase.RemoveLink(uint(todoID), uint(linkedID)); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// linkUsecase returns err from every link method.
+type linkUsecase struct {
+	domain.TodoUsecase
+	err error
+}
+
+func (u *linkUsecase) GetLinks(id uint) ([]domain.TodoLink, error) {
+	return []domain.TodoLink{}, u.err
+}
+
+func (u *linkUsecase) AddLink(id uint, link domain.TodoLink) (*domain.Todo, error) {
+	return &domain.Todo{ID: id}, u.err
+}
+
+func (u *linkUsecase) RemoveLink(id, linkedID uint) error {
+	return u.err
+}
+
+func TestLinkHandlerStatus(t *testing.T) {
+	requests := []struct {
+		method, target, body string
+		success              int
+	}{
+		{http.MethodGet, "/todos/1/links", "", http.StatusOK},
+		{http.MethodPost, "/todos/1/links", `{"type":"relates_to","todo_id":2}`, http.StatusCreated},
+		{http.MethodDelete, "/todos/1/links/2", "", http.StatusNoContent},
+	}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success"},
+		{name: "unknown todo", err: domain.ErrNotFound, want: http.StatusNotFound},
+		{name: "invalid link", err: domain.ErrUnknownLinkedTodo, want: http.StatusBadRequest},
+		{name: "database failure", err: errors.New("connection refused"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		for _, r := range requests {
+			e := echo.New()
+			NewLinkHandler(e, &linkUsecase{err: tt.err})
+			req := httptest.NewRequest(r.method, r.target, strings.NewReader(r.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			want := tt.want
+			if tt.err == nil {
+				want = r.success
+			}
+			if rec.Code != want {
+				t.Errorf("%s: %s %s = %d, want %d", tt.name, r.method, r.target, rec.Code, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectHandler struct {
+	projectUsecase domain.ProjectUsecase
+	todoUsecase    domain.TodoUsecase
+}
+
+// NewProjectHandler initializes the project handler
+func NewProjectHandler(e *echo.Echo, usecase domain.ProjectUsecase, todoUsecase domain.TodoUsecase) {
+	handler := &ProjectHandler{
+		projectUsecase: usecase,
+		todoUsecase:    todoUsecase,
+	}
+
+	e.POST("/projects", handler.Create)
+	e.GET("/projects", handler.GetAll)
+	e.GET("/projects/:id", handler.GetByID)
+	e.GET("/projects/:id/todos", handler.GetTodos)
+	e.POST("/projects/:id/archive-completed", handler.ArchiveCompleted)
+	e.PUT("/projects/:id", handler.Update)
+	e.DELETE("/projects/:id", handler.Delete)
+}
+
+// Create godoc
+// @Summary      Create a new project
+// @Description  Create a new project that groups todos
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        project  body      domain.Project  true  "Project object"
+// @Success      201      {object}  domain.Project
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /projects [post]
+func (h *ProjectHandler) Create(c echo.Context) error {
+	project := new(domain.Project)
+	if err := c.Bind(project); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.projectUsecase.Create(project); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, project)
+}
+
+// GetAll godoc
+// @Summary      List all projects
+// @Description  Get all projects ordered by name
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   domain.Project
+// @Failure      500  {object}  map[string]string
+// @Router       /projects [get]
+func (h *ProjectHandler) GetAll(c echo.Context) error {
+	projects, err := h.projectUsecase.GetAll()
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, projects)
+}
+
+// GetByID godoc
+// @Summary      Get a project by ID
+// @Description  Get a single project by its ID
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Project ID"
+// @Success      200  {object}  domain.Project
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /projects/{id} [get]
+func (h *ProjectHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	project, err := h.projectUsecase.GetByID(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, project)
+}
+
+// GetTodos godoc
+// @Summary      List the todos of a project
+// @Description  Get the todos of a project; accepts the same filters as GET /todos, including cf.<key> custom field filters and sort=cf.<key>
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true   "Project ID"
+// @Param        render  query     string  false  "Also return the descriptions rendered from Markdown to sanitized HTML"  Enums(html)
+// @Param        limit   query     int     false  "Number of todos per page (default 50, at most 200)"
+// @Param        cursor  query     string  false  "next_cursor of the previous page"
+// @Success      200  {object}  domain.TodoPage
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /projects/{id}/todos [get]
+func (h *ProjectHandler) GetTodos(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if _, err := h.projectUsecase.GetByID(uint(id)); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	filter, err := parseTodoFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	projectID := uint(id)
+	filter.ProjectID = &projectID
+	page, err := parsePageRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	render, err := parseRender(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	todos, err := h.todoUsecase.GetAll(filter, page)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if render {
+		if err := h.todoUsecase.RenderDescriptions(todos.Todos); err != nil {
+			return c.JSON(errorStatus(err), map[string]string{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.JSON(http.StatusOK, todos)
+}
+
+// ArchiveCompleted godoc
+// @Summary      Archive the completed todos of a project
+// @Description  Archive every completed todo of a project and report how many were archived
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Project ID"
+// @Success      200  {object}  map[string]int
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /projects/{id}/archive-completed [post]
+func (h *ProjectHandler) ArchiveCompleted(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	archived, err := h.todoUsecase.ArchiveCompleted(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{
+		"archived": archived,
+	})
+}
+
+// Update godoc
+// @Summary      Update a project
+// @Description  Update a project with the provided information
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int             true  "Project ID"
+// @Param        project  body      domain.Project  true  "Project object"
+// @Success      200      {object}  domain.Project
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /projects/{id} [put]
+func (h *ProjectHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	project := new(domain.Project)
+	if err := c.Bind(project); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	project.ID = uint(id)
+	if err := h.projectUsecase.Update(project); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, project)
+}
+
+// Delete godoc
+// @Summary      Delete a project
+// @Description  Delete a project by its ID. A project that still has todos is refused unless cascade=true, which deletes its todos as well.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int   true   "Project ID"
+// @Param        cascade  query     bool  false  "Also delete the project's todos"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /projects/{id} [delete]
+func (h *ProjectHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	cascade := false
+	if v := c.QueryParam("cascade"); v != "" {
+		if cascade, err = strconv.ParseBool(v); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid cascade",
+			})
+		}
+	}
+
+	if err := h.projectUsecase.Delete(uint(id), cascade); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReminderHandler struct {
+	reminderUsecase domain.ReminderUsecase
+}
+
+// NewReminderHandler initializes the reminder handler
+func NewReminderHandler(e *echo.Echo, usecase domain.ReminderUsecase) {
+	handler := &ReminderHandler{
+		reminderUsecase: usecase,
+	}
+
+	e.POST("/todos/:id/reminders", handler.Create)
+	e.GET("/todos/:id/reminders", handler.GetAll)
+	e.DELETE("/todos/:id/reminders/:reminder_id", handler.Delete)
+}
+
+// Create godoc
+// @Summary      Add a reminder to a todo
+// @Description  Schedule a reminder at an absolute time (remind_at) or a number of minutes before the due date of the todo (offset_minutes)
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int              true  "Todo ID"
+// @Param        reminder  body      domain.Reminder  true  "Reminder object"
+// @Success      201       {object}  domain.Reminder
+// @Failure      400       {object}  map[string]string
+// @Failure      404       {object}  map[string]string
+// @Failure      500       {object}  map[string]string
+// @Router       /todos/{id}/reminders [post]
+func (h *ReminderHandler) Create(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	reminder := new(domain.Reminder)
+	if err := c.Bind(reminder); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	reminder.ID = 0
+	reminder.TodoID = uint(todoID)
+	if err := h.reminderUsecase.Create(reminder); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, reminder)
+}
+
+// GetAll godoc
+// @Summary      List the reminders of a todo
+// @Description  Get all reminders of a todo with their delivery status, soonest first
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {array}   domain.Reminder
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/reminders [get]
+func (h *ReminderHandler) GetAll(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	reminders, err := h.reminderUsecase.GetByTodoID(uint(todoID))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, reminders)
+}
+
+// Delete godoc
+// @Summary      Delete a reminder
+// @Description  Remove a reminder from a todo
+// @Tags         reminders
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int  true  "Todo ID"
+// @Param        reminder_id  path      int  true  "Reminder ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/reminders/{reminder_id} [delete]
+func (h *ReminderHandler) Delete(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+	id, err := strconv.ParseUint(c.Param("reminder_id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if err := h.reminderUsecase.Delete(uint(todoID), uint(id)); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
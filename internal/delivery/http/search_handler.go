@@ -0,0 +1,84 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SearchHandler struct {
+	todoUsecase domain.TodoUsecase
+}
+
+// NewSearchHandler initializes the handler for full-text search
+func NewSearchHandler(e *echo.Echo, usecase domain.TodoUsecase) {
+	handler := &SearchHandler{
+		todoUsecase: usecase,
+	}
+
+	e.GET("/todos/search", handler.Search)
+}
+
+// Search godoc
+// @Summary      Search todos
+// @Description  Search the titles and descriptions of todos, most relevant first. q takes words, "quoted phrases" and prefixes like rele*; a leading - excludes a term. title_highlight and snippet are HTML with the matches wrapped in <mark>.
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        q           query     string  true   "Search query"
+// @Param        project_id  query     int     false  "Only todos in this project"
+// @Param        limit       query     int     false  "Number of results (default 50, at most 200)"
+// @Success      200  {array}   domain.SearchResult
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/search [get]
+func (h *SearchHandler) Search(c echo.Context) error {
+	query, err := parseSearchQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	results, err := h.todoUsecase.Search(query)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// parseSearchQuery reads the GET /todos/search query parameters.
+func parseSearchQuery(c echo.Context) (domain.SearchQuery, error) {
+	query := domain.SearchQuery{
+		Text: c.QueryParam("q"),
+	}
+	if query.Text == "" {
+		return query, fmt.Errorf("q is required")
+	}
+
+	if v := c.QueryParam("project_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return query, fmt.Errorf("invalid project_id %q", v)
+		}
+		projectID := uint(id)
+		query.ProjectID = &projectID
+	}
+
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return query, fmt.Errorf("invalid limit %q: must be a positive number", v)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
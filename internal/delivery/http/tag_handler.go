@@ -0,0 +1,174 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TagHandler struct {
+	tagUsecase domain.TagUsecase
+}
+
+// NewTagHandler initializes the tag handler
+func NewTagHandler(e *echo.Echo, usecase domain.TagUsecase) {
+	handler := &TagHandler{
+		tagUsecase: usecase,
+	}
+
+	e.POST("/tags", handler.Create)
+	e.GET("/tags", handler.GetAll)
+	e.GET("/tags/:id", handler.GetByID)
+	e.PUT("/tags/:id", handler.Update)
+	e.DELETE("/tags/:id", handler.Delete)
+}
+
+// Create godoc
+// @Summary      Create a new tag
+// @Description  Create a new tag that can be attached to todos
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        tag  body      domain.Tag  true  "Tag object"
+// @Success      201  {object}  domain.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags [post]
+func (h *TagHandler) Create(c echo.Context) error {
+	tag := new(domain.Tag)
+	if err := c.Bind(tag); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.tagUsecase.Create(tag); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, tag)
+}
+
+// GetAll godoc
+// @Summary      List all tags
+// @Description  Get all tags ordered by name
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   domain.Tag
+// @Failure      500  {object}  map[string]string
+// @Router       /tags [get]
+func (h *TagHandler) GetAll(c echo.Context) error {
+	tags, err := h.tagUsecase.GetAll()
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+// GetByID godoc
+// @Summary      Get a tag by ID
+// @Description  Get a single tag by its ID
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tag ID"
+// @Success      200  {object}  domain.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [get]
+func (h *TagHandler) GetByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	tag, err := h.tagUsecase.GetByID(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// Update godoc
+// @Summary      Rename a tag
+// @Description  Update a tag; every todo carrying it reflects the new name
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int         true  "Tag ID"
+// @Param        tag  body      domain.Tag  true  "Tag object"
+// @Success      200  {object}  domain.Tag
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [put]
+func (h *TagHandler) Update(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	tag := new(domain.Tag)
+	if err := c.Bind(tag); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	tag.ID = uint(id)
+	if err := h.tagUsecase.Update(tag); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// Delete godoc
+// @Summary      Delete a tag
+// @Description  Delete a tag by its ID and remove it from every todo
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tag ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /tags/{id} [delete]
+func (h *TagHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if err := h.tagUsecase.Delete(uint(id)); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
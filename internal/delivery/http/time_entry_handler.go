@@ -0,0 +1,307 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TimeEntryHandler struct {
+	timeEntryUsecase domain.TimeEntryUsecase
+}
+
+// NewTimeEntryHandler initializes the time tracking handler
+func NewTimeEntryHandler(e *echo.Echo, usecase domain.TimeEntryUsecase) {
+	handler := &TimeEntryHandler{
+		timeEntryUsecase: usecase,
+	}
+
+	e.POST("/todos/:id/timer/start", handler.StartTimer)
+	e.POST("/todos/:id/timer/stop", handler.StopTimer)
+	e.POST("/todos/:id/time-entries", handler.Create)
+	e.GET("/todos/:id/time-entries", handler.GetAll)
+	e.DELETE("/todos/:id/time-entries/:entry_id", handler.Delete)
+	e.GET("/reports/time", handler.Report)
+}
+
+// StartTimer godoc
+// @Summary      Start a timer on a todo
+// @Description  Start tracking the time a user spends on a todo. A user can only have one running timer.
+// @Tags         time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                  true  "Todo ID"
+// @Param        timer  body      domain.TimerRequest  true  "User starting the timer"
+// @Success      201    {object}  domain.TimeEntry
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /todos/{id}/timer/start [post]
+func (h *TimeEntryHandler) StartTimer(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	var timer domain.TimerRequest
+	if err := c.Bind(&timer); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	entry, err := h.timeEntryUsecase.StartTimer(uint(todoID), timer.User)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, entry)
+}
+
+// StopTimer godoc
+// @Summary      Stop a timer on a todo
+// @Description  Stop the running timer of a user on a todo
+// @Tags         time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                  true  "Todo ID"
+// @Param        timer  body      domain.TimerRequest  true  "User stopping the timer"
+// @Success      200    {object}  domain.TimeEntry
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      409    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /todos/{id}/timer/stop [post]
+func (h *TimeEntryHandler) StopTimer(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	var timer domain.TimerRequest
+	if err := c.Bind(&timer); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	entry, err := h.timeEntryUsecase.StopTimer(uint(todoID), timer.User)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, entry)
+}
+
+// Create godoc
+// @Summary      Record time manually
+// @Description  Add a finished time entry with started_at and ended_at to a todo
+// @Tags         time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int               true  "Todo ID"
+// @Param        entry  body      domain.TimeEntry  true  "Time entry"
+// @Success      201    {object}  domain.TimeEntry
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /todos/{id}/time-entries [post]
+func (h *TimeEntryHandler) Create(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	entry := new(domain.TimeEntry)
+	if err := c.Bind(entry); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	entry.ID = 0
+	entry.TodoID = uint(todoID)
+	if err := h.timeEntryUsecase.Create(entry); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, entry)
+}
+
+// GetAll godoc
+// @Summary      List the time entries of a todo
+// @Description  Get all time entries of a todo, including running timers, oldest first
+// @Tags         time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {array}   domain.TimeEntry
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/time-entries [get]
+func (h *TimeEntryHandler) GetAll(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	entries, err := h.timeEntryUsecase.GetByTodoID(uint(todoID))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
+
+// Delete godoc
+// @Summary      Delete a time entry
+// @Description  Remove a time entry from a todo
+// @Tags         time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int  true  "Todo ID"
+// @Param        entry_id  path      int  true  "Time entry ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/time-entries/{entry_id} [delete]
+func (h *TimeEntryHandler) Delete(c echo.Context) error {
+	todoID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+	id, err := strconv.ParseUint(c.Param("entry_id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if err := h.timeEntryUsecase.Delete(uint(todoID), uint(id)); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Report godoc
+// @Summary      Time report
+// @Description  Sum the time tracked per todo and per project between from and to. Both accept a date, which is interpreted in tz and includes the whole day for to, or an RFC 3339 timestamp.
+// @Tags         time tracking
+// @Accept       json
+// @Produce      json
+// @Param        from        query     string  true   "Start of the range"
+// @Param        to          query     string  true   "End of the range"
+// @Param        tz          query     string  false  "IANA time zone for dates (default UTC)"
+// @Param        project_id  query     int     false  "Only time on todos in this project"
+// @Param        user        query     string  false  "Only time tracked by this user"
+// @Success      200  {object}  domain.TimeReport
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /reports/time [get]
+func (h *TimeEntryHandler) Report(c echo.Context) error {
+	filter, err := parseTimeReportFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	report, err := h.timeEntryUsecase.Report(filter)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// parseTimeReportFilter reads the GET /reports/time query parameters.
+func parseTimeReportFilter(c echo.Context) (domain.TimeReportFilter, error) {
+	filter := domain.TimeReportFilter{
+		User: c.QueryParam("user"),
+	}
+
+	loc := time.UTC
+	if v := c.QueryParam("tz"); v != "" {
+		var err error
+		if loc, err = time.LoadLocation(v); err != nil {
+			return filter, fmt.Errorf("invalid tz %q", v)
+		}
+	}
+
+	var err error
+	if filter.From, err = parseReportTime(c, "from", loc, false); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseReportTime(c, "to", loc, true); err != nil {
+		return filter, err
+	}
+
+	if v := c.QueryParam("project_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("invalid project_id %q", v)
+		}
+		projectID := uint(id)
+		filter.ProjectID = &projectID
+	}
+
+	return filter, nil
+}
+
+// parseReportTime reads a required query parameter holding either an RFC
+// 3339 timestamp or a date, see parseTimeOrDate.
+func parseReportTime(c echo.Context, name string, loc *time.Location, endOfDay bool) (time.Time, error) {
+	v := c.QueryParam(name)
+	if v == "" {
+		return time.Time{}, fmt.Errorf("%s is required", name)
+	}
+	return parseTimeOrDate(name, v, loc, endOfDay)
+}
+
+// parseTimeOrDate parses the value v of the query parameter name as an RFC
+// 3339 timestamp or a date. A date stands for the start of that day in loc,
+// or for its end if endOfDay is set.
+func parseTimeOrDate(name, v string, loc *time.Location, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation(time.DateOnly, v, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: must be a date or an RFC 3339 timestamp", name, v)
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
@@ -0,0 +1,238 @@
+package http
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// customFieldParamPrefix marks query parameters that filter or sort on a
+// project's custom fields, e.g. cf.points=3 or sort=cf.points.
+const customFieldParamPrefix = "cf."
+
+// todoQueryParams lists the query parameters of GET /todos besides the
+// cf.<key> custom field filters, and whether they may be repeated. Other
+// parameters are rejected, so that a typo does not quietly list todos that
+// were meant to be filtered out.
+var todoQueryParams = map[string]bool{
+	"project_id":       false,
+	"status":           true,
+	"completed":        false,
+	"completed_after":  false,
+	"completed_before": false,
+	"title":            false,
+	"description":      false,
+	"created_after":    false,
+	"created_before":   false,
+	"updated_after":    false,
+	"updated_before":   false,
+	"id":               true,
+	"due":              false,
+	"due_within":       false,
+	"tz":               false,
+	"priority":         true,
+	"min_priority":     false,
+	"tag":              true,
+	"tag_match":        false,
+	"actionable":       false,
+	"archived":         false,
+	"sort":             false,
+	"render":           false,
+	"limit":            false,
+	"cursor":           false,
+	"q":                false,
+}
+
+// checkTodoQueryParams rejects unknown and wrongly repeated query parameters.
+func checkTodoQueryParams(c echo.Context) error {
+	params := c.QueryParams()
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.HasPrefix(name, customFieldParamPrefix) {
+			continue
+		}
+		repeatable, ok := todoQueryParams[name]
+		if !ok {
+			return fmt.Errorf("unknown query parameter %q", name)
+		}
+		if !repeatable && len(params[name]) > 1 {
+			return fmt.Errorf("query parameter %q must be given only once", name)
+		}
+	}
+	return nil
+}
+
+// parseTodoFilter reads the GET /todos query parameters into a domain.TodoFilter.
+func parseTodoFilter(c echo.Context) (domain.TodoFilter, error) {
+	if err := checkTodoQueryParams(c); err != nil {
+		return domain.TodoFilter{}, err
+	}
+
+	filter := domain.TodoFilter{
+		Due:         domain.DueFilter(c.QueryParam("due")),
+		MinPriority: domain.Priority(c.QueryParam("min_priority")),
+		Tags:        c.QueryParams()["tag"],
+		TagMatch:    domain.TagMatch(c.QueryParam("tag_match")),
+		Archived:    domain.ArchivedFilter(c.QueryParam("archived")),
+		Title:       c.QueryParam("title"),
+		Description: c.QueryParam("description"),
+		Query:       c.QueryParam("q"),
+	}
+	for _, p := range c.QueryParams()["priority"] {
+		filter.Priorities = append(filter.Priorities, domain.Priority(p))
+	}
+	for _, s := range c.QueryParams()["status"] {
+		filter.Statuses = append(filter.Statuses, domain.Status(s))
+	}
+
+	filter.Sort = parseSort(c.QueryParam("sort"))
+	for name, values := range c.QueryParams() {
+		key, ok := strings.CutPrefix(name, customFieldParamPrefix)
+		if !ok {
+			continue
+		}
+		if key == "" || len(values) != 1 {
+			return filter, fmt.Errorf("invalid custom field filter %q: expected one value", name)
+		}
+		if filter.CustomFields == nil {
+			filter.CustomFields = make(map[string]string)
+		}
+		filter.CustomFields[key] = values[0]
+	}
+
+	if v := c.QueryParam("project_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("invalid project_id %q", v)
+		}
+		projectID := uint(id)
+		filter.ProjectID = &projectID
+	}
+
+	for _, v := range c.QueryParams()["id"] {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || id == 0 {
+			return filter, fmt.Errorf("invalid id %q", v)
+		}
+		filter.IDs = append(filter.IDs, uint(id))
+	}
+
+	if v := c.QueryParam("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid completed %q", v)
+		}
+		filter.Completed = &completed
+	}
+
+	if v := c.QueryParam("actionable"); v != "" {
+		actionable, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid actionable %q", v)
+		}
+		filter.Actionable = actionable
+	}
+
+	if v := c.QueryParam("due_within"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			return filter, fmt.Errorf("invalid due_within %q: must be a positive number of days", v)
+		}
+		filter.DueWithinDays = days
+	}
+
+	if v := c.QueryParam("tz"); v != "" {
+		loc, err := time.LoadLocation(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid tz %q", v)
+		}
+		filter.Location = loc
+	}
+
+	loc := filter.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	ranges := []struct {
+		name     string
+		endOfDay bool
+		dest     **time.Time
+	}{
+		{"completed_after", false, &filter.CompletedAfter},
+		{"completed_before", true, &filter.CompletedBefore},
+		{"created_after", false, &filter.CreatedAfter},
+		{"created_before", true, &filter.CreatedBefore},
+		{"updated_after", false, &filter.UpdatedAfter},
+		{"updated_before", true, &filter.UpdatedBefore},
+	}
+	for _, r := range ranges {
+		if v := c.QueryParam(r.name); v != "" {
+			t, err := parseTimeOrDate(r.name, v, loc, r.endOfDay)
+			if err != nil {
+				return filter, err
+			}
+			*r.dest = &t
+		}
+	}
+
+	return filter, nil
+}
+
+// parseSort reads a comma separated list of fields to sort by, each
+// descending if prefixed with -, e.g. -updated_at,title. Fields prefixed
+// with cf. are custom fields. Whether the fields exist is checked later.
+func parseSort(v string) []domain.SortKey {
+	if v == "" {
+		return nil
+	}
+	var keys []domain.SortKey
+	for _, field := range strings.Split(v, ",") {
+		var key domain.SortKey
+		field, key.Desc = strings.CutPrefix(strings.TrimSpace(field), "-")
+		if name, ok := strings.CutPrefix(field, customFieldParamPrefix); ok {
+			key.CustomField = name
+		} else {
+			key.Field = field
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// parsePageRequest reads the limit and cursor query parameters.
+func parsePageRequest(c echo.Context) (domain.PageRequest, error) {
+	page := domain.PageRequest{
+		Cursor: c.QueryParam("cursor"),
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return page, fmt.Errorf("invalid limit %q: must be a positive number", v)
+		}
+		page.Limit = limit
+	}
+	return page, nil
+}
+
+// parseRender reports whether render=html asks for the descriptions to be
+// rendered from Markdown as well.
+func parseRender(c echo.Context) (bool, error) {
+	switch v := c.QueryParam("render"); v {
+	case "":
+		return false, nil
+	case "html":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid render %q: must be html", v)
+	}
+}
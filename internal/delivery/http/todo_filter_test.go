@@ -0,0 +1,134 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+func todoFilterContext(query string) echo.Context {
+	req := httptest.NewRequest(http.MethodGet, "/todos?"+query, nil)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestParseTodoFilter(t *testing.T) {
+	yes := true
+	projectID := uint(3)
+	day := func(day int, loc *time.Location) *time.Time {
+		t := time.Date(2026, 10, day, 0, 0, 0, 0, loc)
+		return &t
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		check func(f domain.TodoFilter) bool
+	}{
+		{
+			name:  "no filters",
+			query: "",
+			check: func(f domain.TodoFilter) bool { return reflect.DeepEqual(f, domain.TodoFilter{}) },
+		},
+		{
+			name:  "completed, text and project",
+			query: "completed=true&title=release&description=notes&project_id=3",
+			check: func(f domain.TodoFilter) bool {
+				return reflect.DeepEqual(f.Completed, &yes) && f.Title == "release" && f.Description == "notes" &&
+					reflect.DeepEqual(f.ProjectID, &projectID)
+			},
+		},
+		{
+			name:  "ID list",
+			query: "id=4&id=2&id=4",
+			check: func(f domain.TodoFilter) bool { return reflect.DeepEqual(f.IDs, []uint{4, 2, 4}) },
+		},
+		{
+			name:  "dates cover whole days",
+			query: "created_after=2026-10-12&created_before=2026-10-18",
+			check: func(f domain.TodoFilter) bool {
+				return f.CreatedAfter.Equal(*day(12, time.UTC)) && f.CreatedBefore.Equal(*day(19, time.UTC))
+			},
+		},
+		{
+			name:  "dates in a time zone",
+			query: "updated_after=2026-10-12&tz=Europe/Berlin",
+			check: func(f domain.TodoFilter) bool { return f.UpdatedAfter.Equal(*day(12, berlin)) },
+		},
+		{
+			name:  "timestamps",
+			query: "completed_after=2026-10-12T08:00:00%2B02:00&completed_before=2026-10-18T00:00:00Z",
+			check: func(f domain.TodoFilter) bool {
+				return f.CompletedAfter.Equal(time.Date(2026, 10, 12, 6, 0, 0, 0, time.UTC)) && f.CompletedBefore.Equal(*day(18, time.UTC))
+			},
+		},
+		{
+			name:  "custom fields",
+			query: "cf.points=3&sort=-cf.points,title",
+			check: func(f domain.TodoFilter) bool {
+				return reflect.DeepEqual(f.CustomFields, map[string]string{"points": "3"}) &&
+					reflect.DeepEqual(f.Sort, []domain.SortKey{{CustomField: "points", Desc: true}, {Field: "title"}})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseTodoFilter(todoFilterContext(tt.query))
+			if err != nil {
+				t.Fatalf("parseTodoFilter(%q) error = %v", tt.query, err)
+			}
+			if !tt.check(filter) {
+				t.Errorf("parseTodoFilter(%q) = %+v", tt.query, filter)
+			}
+		})
+	}
+}
+
+func TestParseTodoFilterErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantMsg string
+	}{
+		{"done=true", `unknown query parameter "done"`},
+		{"completed=true&completed=false", `query parameter "completed" must be given only once`},
+		{"completed=maybe", `invalid completed "maybe"`},
+		{"id=0", `invalid id "0"`},
+		{"id=4&id=x", `invalid id "x"`},
+		{"project_id=-1", `invalid project_id "-1"`},
+		{"due_within=0", `invalid due_within "0"`},
+		{"tz=Mars/Olympus", `invalid tz "Mars/Olympus"`},
+		{"created_after=yesterday", `invalid created_after "yesterday"`},
+		{"updated_before=2026-13-01", `invalid updated_before "2026-13-01"`},
+		{"cf.=3", `invalid custom field filter "cf."`},
+		{"cf.points=3&cf.points=4", `invalid custom field filter "cf.points"`},
+	}
+	for _, tt := range tests {
+		_, err := parseTodoFilter(todoFilterContext(tt.query))
+		if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+			t.Errorf("parseTodoFilter(%q) error = %v, want it to contain %q", tt.query, err, tt.wantMsg)
+		}
+	}
+}
+
+func TestGetAllTodosRejectsBadFilters(t *testing.T) {
+	e := echo.New()
+	// The usecase implements no methods, so reaching it would panic.
+	NewTodoHandler(e, struct{ domain.TodoUsecase }{})
+	for _, query := range []string{"done=true", "completed=maybe", "id=x"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/todos?"+query, nil))
+		if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"error"`) {
+			t.Errorf("GET /todos?%s = %d %s, want %d with an error", query, rec.Code, rec.Body, http.StatusBadRequest)
+		}
+	}
+}
@@ -22,8 +22,16 @@ func NewTodoHandler(e *echo.Echo, usecase domain.TodoUsecase) {
 	e.POST("/todos", handler.Create)
 	e.GET("/todos", handler.GetAll)
 	e.GET("/todos/:id", handler.GetByID)
+	e.GET("/todos/:id/children", handler.GetChildren)
+	e.GET("/todos/:id/history", handler.GetHistory)
 	e.PUT("/todos/:id", handler.Update)
+	e.POST("/todos/:id/move", handler.Move)
+	e.POST("/todos/:id/recurrence/skip", handler.SkipOccurrence)
+	e.POST("/todos/:id/recurrence/stop", handler.StopRecurrence)
+	e.POST("/todos/:id/archive", handler.Archive)
+	e.POST("/todos/:id/unarchive", handler.Unarchive)
 	e.DELETE("/todos/:id", handler.Delete)
+	e.GET("/workflow", handler.GetWorkflow)
 }
 
 // Create godoc
@@ -46,7 +54,7 @@ func (h *TodoHandler) Create(c echo.Context) error {
 	}
 
 	if err := h.todoUsecase.Create(todo); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
+		return c.JSON(errorStatus(err), map[string]string{
 			"error": err.Error(),
 		})
 	}
@@ -56,21 +64,75 @@ func (h *TodoHandler) Create(c echo.Context) error {
 
 // GetAll godoc
 // @Summary      List all todos
-// @Description  Get all todos from the system
+// @Description  Get a page of todos, optionally narrowed down by project, status, text, dates, priority, tags or blockers, also combined into a single query in q. Pass next_cursor as cursor to get the next page. Unknown query parameters are rejected.
 // @Tags         todos
 // @Accept       json
 // @Produce      json
-// @Success      200  {array}   domain.Todo
+// @Param        q             query     string    false  "Query combining filters and text, e.g. status:open tag:backend due<2026-11-01 \"release notes\""
+// @Param        project_id    query     int       false  "Only todos in this project"
+// @Param        status        query     []string  false  "Only todos with one of these statuses"  collectionFormat(multi)
+// @Param        completed     query     bool      false  "Only completed or only open todos"
+// @Param        id            query     []int     false  "Only todos with one of these IDs"  collectionFormat(multi)
+// @Param        title         query     string    false  "Only todos whose title contains this text, ignoring case"
+// @Param        description   query     string    false  "Only todos whose description contains this text, ignoring case"
+// @Param        created_after     query  string    false  "Only todos created at or after this RFC 3339 time or date (in tz)"
+// @Param        created_before    query  string    false  "Only todos created before this RFC 3339 time or on or before this date (in tz)"
+// @Param        updated_after     query  string    false  "Only todos last updated at or after this RFC 3339 time or date (in tz)"
+// @Param        updated_before    query  string    false  "Only todos last updated before this RFC 3339 time or on or before this date (in tz)"
+// @Param        due           query     string    false  "Due date selection"  Enums(overdue, today)
+// @Param        due_within    query     int       false  "Only open todos due within the next N days"
+// @Param        tz            query     string    false  "IANA time zone used for today (default UTC)"
+// @Param        priority      query     []string  false  "Only todos with one of these priorities"  collectionFormat(multi)
+// @Param        min_priority  query     string    false  "Only todos at or above this priority"
+// @Param        tag           query     []string  false  "Only todos carrying these tag names"  collectionFormat(multi)
+// @Param        tag_match     query     string    false  "Whether todos need any or all of the tags (default any)"  Enums(any, all)
+// @Param        actionable    query     bool      false  "Only open todos that are not blocked by open todos"
+// @Param        completed_after   query  string    false  "Only todos completed at or after this RFC 3339 time or date (in tz)"
+// @Param        completed_before  query  string    false  "Only todos completed before this RFC 3339 time or on or before this date (in tz)"
+// @Param        archived      query     string    false  "Also list archived todos, or only them (default neither)"  Enums(include, only)
+// @Param        cf.{key}      query     string    false  "Only todos whose custom field key has this value; needs project_id"
+// @Param        sort          query     string    false  "Comma separated fields to sort by, descending if prefixed with -, e.g. -updated_at,title (default manual order); every field sorts ascending unless prefixed with -, so -priority,due_at lists the most urgent first; cf.<key> sorts by a custom field of project_id"
+// @Param        render        query     string    false  "Also return the description rendered from Markdown to sanitized HTML"  Enums(html)
+// @Param        limit         query     int       false  "Number of todos per page (default 50, at most 200)"
+// @Param        cursor        query     string    false  "next_cursor of the previous page"
+// @Success      200  {object}  domain.TodoPage
+// @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /todos [get]
 func (h *TodoHandler) GetAll(c echo.Context) error {
-	todos, err := h.todoUsecase.GetAll()
+	filter, err := parseTodoFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	page, err := parsePageRequest(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	render, err := parseRender(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": err.Error(),
 		})
 	}
 
+	todos, err := h.todoUsecase.GetAll(filter, page)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if render {
+		if err := h.todoUsecase.RenderDescriptions(todos.Todos); err != nil {
+			return c.JSON(errorStatus(err), map[string]string{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	return c.JSON(http.StatusOK, todos)
 }
 
@@ -80,7 +142,8 @@ func (h *TodoHandler) GetAll(c echo.Context) error {
 // @Tags         todos
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "Todo ID"
+// @Param        id      path      int     true   "Todo ID"
+// @Param        render  query     string  false  "Also return the description rendered from Markdown to sanitized HTML"  Enums(html)
 // @Success      200  {object}  domain.Todo
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
@@ -93,20 +156,107 @@ func (h *TodoHandler) GetByID(c echo.Context) error {
 			"error": "Invalid ID",
 		})
 	}
+	render, err := parseRender(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
 
 	todo, err := h.todoUsecase.GetByID(uint(id))
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "Todo not found",
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
 		})
 	}
+	if render {
+		if err := h.todoUsecase.RenderDescriptions([]*domain.Todo{todo}); err != nil {
+			return c.JSON(errorStatus(err), map[string]string{
+				"error": err.Error(),
+			})
+		}
+	}
 
 	return c.JSON(http.StatusOK, todo)
 }
 
+// GetChildren godoc
+// @Summary      List the subtasks of a todo
+// @Description  Get the direct children of a todo
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true   "Todo ID"
+// @Param        render  query     string  false  "Also return the descriptions rendered from Markdown to sanitized HTML"  Enums(html)
+// @Success      200  {array}   domain.Todo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/children [get]
+func (h *TodoHandler) GetChildren(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+	render, err := parseRender(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	children, err := h.todoUsecase.GetChildren(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if render {
+		if err := h.todoUsecase.RenderDescriptions(children); err != nil {
+			return c.JSON(errorStatus(err), map[string]string{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.JSON(http.StatusOK, children)
+}
+
+// GetHistory godoc
+// @Summary      Get the completion history of a todo
+// @Description  Get when a todo was completed, closed and reopened, oldest first
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {array}   domain.TodoEvent
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/history [get]
+func (h *TodoHandler) GetHistory(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	events, err := h.todoUsecase.GetHistory(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, events)
+}
+
 // Update godoc
 // @Summary      Update a todo
-// @Description  Update a todo with the provided information
+// @Description  Update a todo with the provided information. Status changes must be allowed by the workflow (see GET /workflow). Completing a recurring todo creates its next occurrence.
 // @Tags         todos
 // @Accept       json
 // @Produce      json
@@ -115,6 +265,7 @@ func (h *TodoHandler) GetByID(c echo.Context) error {
 // @Success      200   {object}  domain.Todo
 // @Failure      400   {object}  map[string]string
 // @Failure      404   {object}  map[string]string
+// @Failure      409   {object}  map[string]string
 // @Failure      500   {object}  map[string]string
 // @Router       /todos/{id} [put]
 func (h *TodoHandler) Update(c echo.Context) error {
@@ -134,7 +285,166 @@ func (h *TodoHandler) Update(c echo.Context) error {
 
 	todo.ID = uint(id)
 	if err := h.todoUsecase.Update(todo); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Move godoc
+// @Summary      Move a todo in the manual order
+// @Description  Place a todo directly before or after another todo. Only the moved todo changes.
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int              true  "Todo ID"
+// @Param        move  body      domain.TodoMove  true  "Todo to move next to"
+// @Success      200   {object}  domain.Todo
+// @Failure      400   {object}  map[string]string
+// @Failure      404   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /todos/{id}/move [post]
+func (h *TodoHandler) Move(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	var move domain.TodoMove
+	if err := c.Bind(&move); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	todo, err := h.todoUsecase.Move(uint(id), move)
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// SkipOccurrence godoc
+// @Summary      Skip an occurrence of a recurring todo
+// @Description  Move a recurring todo on to its next occurrence without completing it
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {object}  domain.Todo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/recurrence/skip [post]
+func (h *TodoHandler) SkipOccurrence(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	todo, err := h.todoUsecase.SkipOccurrence(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// StopRecurrence godoc
+// @Summary      Stop a todo from recurring
+// @Description  Remove the recurrence rule so the todo becomes a one-off todo
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {object}  domain.Todo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/recurrence/stop [post]
+func (h *TodoHandler) StopRecurrence(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	todo, err := h.todoUsecase.StopRecurrence(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Archive godoc
+// @Summary      Archive a todo
+// @Description  Hide a todo from GET /todos unless archived todos are requested
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {object}  domain.Todo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/archive [post]
+func (h *TodoHandler) Archive(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	todo, err := h.todoUsecase.Archive(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Unarchive godoc
+// @Summary      Unarchive a todo
+// @Description  Bring an archived todo back into GET /todos
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {object}  domain.Todo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /todos/{id}/unarchive [post]
+func (h *TodoHandler) Unarchive(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	todo, err := h.todoUsecase.Unarchive(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
 			"error": err.Error(),
 		})
 	}
@@ -142,13 +452,26 @@ func (h *TodoHandler) Update(c echo.Context) error {
 	return c.JSON(http.StatusOK, todo)
 }
 
+// GetWorkflow godoc
+// @Summary      Get the todo workflow
+// @Description  Get the statuses a todo can have and the status changes allowed from each of them
+// @Tags         todos
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  domain.Workflow
+// @Router       /workflow [get]
+func (h *TodoHandler) GetWorkflow(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.todoUsecase.Workflow())
+}
+
 // Delete godoc
 // @Summary      Delete a todo
-// @Description  Delete a todo by its ID
+// @Description  Move a todo to the trash. Subtasks move up to the todo's parent unless children=cascade is given, which deletes them along with it.
 // @Tags         todos
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "Todo ID"
+// @Param        id        path      int     true   "Todo ID"
+// @Param        children  query     string  false  "What happens to subtasks (default reparent)"  Enums(reparent, cascade)
 // @Success      204  "No Content"
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
@@ -162,8 +485,9 @@ func (h *TodoHandler) Delete(c echo.Context) error {
 		})
 	}
 
-	if err := h.todoUsecase.Delete(uint(id)); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
+	children := domain.ChildPolicy(c.QueryParam("children"))
+	if err := h.todoUsecase.Delete(uint(id), children); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
 			"error": err.Error(),
 		})
 	}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TrashHandler struct {
+	todoUsecase domain.TodoUsecase
+}
+
+// NewTrashHandler initializes the handler for deleted todos
+func NewTrashHandler(e *echo.Echo, usecase domain.TodoUsecase) {
+	handler := &TrashHandler{
+		todoUsecase: usecase,
+	}
+
+	e.GET("/trash", handler.GetAll)
+	e.POST("/trash/:id/restore", handler.Restore)
+	e.DELETE("/trash/:id", handler.Delete)
+}
+
+// GetAll godoc
+// @Summary      List deleted todos
+// @Description  Get the todos in the trash, most recently deleted first
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   domain.Todo
+// @Failure      500  {object}  map[string]string
+// @Router       /trash [get]
+func (h *TrashHandler) GetAll(c echo.Context) error {
+	todos, err := h.todoUsecase.GetTrash()
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todos)
+}
+
+// Restore godoc
+// @Summary      Restore a deleted todo
+// @Description  Bring a todo back from the trash together with the subtasks deleted along with it. A subtask whose parent is still in the trash cannot be restored on its own.
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      200  {object}  domain.Todo
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /trash/{id}/restore [post]
+func (h *TrashHandler) Restore(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	todo, err := h.todoUsecase.Restore(uint(id))
+	if err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, todo)
+}
+
+// Delete godoc
+// @Summary      Permanently delete a todo
+// @Description  Remove a todo from the trash for good, together with the subtasks deleted along with it and its comments, attachments, reminders and time entries
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Todo ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /trash/{id} [delete]
+func (h *TrashHandler) Delete(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid ID",
+		})
+	}
+
+	if err := h.todoUsecase.Purge(uint(id)); err != nil {
+		return c.JSON(errorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
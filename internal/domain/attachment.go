@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+type Attachment struct {
+	ID          uint      `json:"id"`
+	TodoID      uint      `json:"todo_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	StorageKey  string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BlobStore keeps the content of attachments. Implementations must stream
+// data instead of holding whole files in memory.
+type BlobStore interface {
+	// Put stores everything read from r under key and returns the number of
+	// bytes written.
+	Put(key string, r io.Reader) (int64, error)
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+type AttachmentRepository interface {
+	Create(attachment *Attachment) error
+	GetByID(id uint) (*Attachment, error)
+	GetByTodoID(todoID uint) ([]*Attachment, error)
+	Delete(id uint) error
+}
+
+// AttachmentUsecase manages the files of a todo. Like comments, attachments
+// are always addressed through their todo.
+type AttachmentUsecase interface {
+	Upload(attachment *Attachment, content io.Reader) error
+	GetByID(todoID, id uint) (*Attachment, error)
+	GetByTodoID(todoID uint) ([]*Attachment, error)
+	// Open returns the attachment together with its content, which the
+	// caller must close.
+	Open(todoID, id uint) (*Attachment, io.ReadCloser, error)
+	Delete(todoID, id uint) error
+}
@@ -0,0 +1,15 @@
+package domain
+
+// ChecklistItem is a lightweight step inside a todo. Unlike a subtask it has
+// no fields of its own besides its text and whether it is checked.
+type ChecklistItem struct {
+	ID      uint   `json:"id"`
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+// ChecklistOrder lists the IDs of all checklist items of a todo in their
+// new order.
+type ChecklistOrder struct {
+	ItemIDs []uint `json:"item_ids"`
+}
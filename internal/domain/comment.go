@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type Comment struct {
+	ID        uint      `json:"id"`
+	TodoID    uint      `json:"todo_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CommentRepository interface {
+	Create(comment *Comment) error
+	GetByID(id uint) (*Comment, error)
+	GetByTodoID(todoID uint) ([]*Comment, error)
+	Update(comment *Comment) error
+	Delete(id uint) error
+}
+
+// CommentUsecase manages the comment thread of a todo. Comments are always
+// addressed through their todo, so a comment ID from another todo is not found.
+type CommentUsecase interface {
+	Create(comment *Comment) error
+	GetByID(todoID, id uint) (*Comment, error)
+	GetByTodoID(todoID uint) ([]*Comment, error)
+	Update(comment *Comment) error
+	Delete(todoID, id uint) error
+}
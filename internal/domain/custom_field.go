@@ -0,0 +1,35 @@
+package domain
+
+// CustomFieldType is the type of the values a custom field accepts.
+type CustomFieldType string
+
+const (
+	CustomFieldText   CustomFieldType = "text"
+	CustomFieldNumber CustomFieldType = "number"
+	// CustomFieldDate values are dates in the form 2006-01-02.
+	CustomFieldDate CustomFieldType = "date"
+	// CustomFieldEnum values are one of the options of the field.
+	CustomFieldEnum    CustomFieldType = "enum"
+	CustomFieldBoolean CustomFieldType = "boolean"
+)
+
+// CustomFieldDefinition describes a field that the todos of a project can
+// carry in addition to the built-in ones. Key is the name under which values
+// are stored in Todo.CustomFields.
+type CustomFieldDefinition struct {
+	Key      string          `json:"key"`
+	Name     string          `json:"name"`
+	Type     CustomFieldType `json:"type"`
+	Options  []string        `json:"options,omitempty"`
+	Required bool            `json:"required"`
+}
+
+// CustomFieldDefinitionFor returns the definition of the custom field key.
+func (p *Project) CustomFieldDefinitionFor(key string) (CustomFieldDefinition, bool) {
+	for _, field := range p.CustomFields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return CustomFieldDefinition{}, false
+}
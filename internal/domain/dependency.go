@@ -0,0 +1,8 @@
+package domain
+
+// Dependency states that a todo is blocked by another one and cannot be
+// completed while the blocking todo is still open.
+type Dependency struct {
+	TodoID      uint `json:"todo_id"`
+	BlockedByID uint `json:"blocked_by_id"`
+}
@@ -1,7 +1,81 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrNotFound = errors.New("record not found")
-) 
+	ErrNotFound     = errors.New("record not found")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrConflict     = errors.New("conflict")
+	ErrTooLarge     = errors.New("too large")
+
+	ErrInvalidDueDate  = fmt.Errorf("%w: due date must be a valid timestamp with timezone", ErrInvalidInput)
+	ErrInvalidFilter   = fmt.Errorf("%w: invalid filter", ErrInvalidInput)
+	ErrInvalidCursor   = fmt.Errorf("%w: cursor is invalid or belongs to another sort order", ErrInvalidInput)
+	ErrInvalidLimit    = fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, MaxPageLimit)
+	ErrInvalidSearch   = fmt.Errorf("%w: invalid search", ErrInvalidInput)
+	ErrInvalidQuery    = fmt.Errorf("%w: invalid query", ErrInvalidInput)
+	ErrInvalidStatus   = fmt.Errorf("%w: status is not a state of the workflow", ErrInvalidInput)
+	ErrInvalidWorkflow = fmt.Errorf("%w: invalid workflow", ErrInvalidInput)
+	ErrInvalidPriority = fmt.Errorf("%w: priority must be one of none, low, medium, high, urgent", ErrInvalidInput)
+	ErrInvalidTagName  = fmt.Errorf("%w: tag name must be between 1 and 50 characters", ErrInvalidInput)
+	ErrUnknownTag      = fmt.Errorf("%w: unknown tag", ErrInvalidInput)
+	ErrUnknownParent   = fmt.Errorf("%w: parent todo does not exist", ErrInvalidInput)
+	ErrTodoCycle       = fmt.Errorf("%w: a todo cannot be nested below itself", ErrInvalidInput)
+	ErrTodoTooDeep     = fmt.Errorf("%w: subtasks are nested too deeply", ErrInvalidInput)
+	ErrInvalidChildren = fmt.Errorf("%w: children must be reparent or cascade", ErrInvalidInput)
+	ErrInvalidProject  = fmt.Errorf("%w: project name must be between 1 and 100 characters", ErrInvalidInput)
+	ErrUnknownProject  = fmt.Errorf("%w: project does not exist", ErrInvalidInput)
+
+	ErrInvalidChecklistItem  = fmt.Errorf("%w: checklist item text must be between 1 and 500 characters", ErrInvalidInput)
+	ErrChecklistTooLong      = fmt.Errorf("%w: a checklist can have at most 100 items", ErrInvalidInput)
+	ErrInvalidChecklistOrder = fmt.Errorf("%w: item_ids must list every checklist item exactly once", ErrInvalidInput)
+
+	ErrInvalidMove       = fmt.Errorf("%w: a move needs either before or after set to the ID of another todo", ErrInvalidInput)
+	ErrUnknownMoveTarget = fmt.Errorf("%w: the todo to move next to does not exist", ErrInvalidInput)
+
+	ErrInvalidEstimate      = fmt.Errorf("%w: estimate_minutes must not be negative", ErrInvalidInput)
+	ErrInvalidTimeEntryUser = fmt.Errorf("%w: user must be between 1 and 100 characters", ErrInvalidInput)
+	ErrInvalidTimeEntry     = fmt.Errorf("%w: a time entry needs a started_at before its ended_at, neither in the future", ErrInvalidInput)
+	ErrInvalidTimeRange     = fmt.Errorf("%w: from must be before to", ErrInvalidInput)
+
+	ErrInvalidCustomField      = fmt.Errorf("%w: invalid custom field", ErrInvalidInput)
+	ErrInvalidCustomValue      = fmt.Errorf("%w: invalid custom field value", ErrInvalidInput)
+	ErrCustomFieldsNeedProject = fmt.Errorf("%w: custom fields need a project_id", ErrInvalidInput)
+
+	ErrUnknownBlocker  = fmt.Errorf("%w: blocking todo does not exist", ErrInvalidInput)
+	ErrDependencyCycle = fmt.Errorf("%w: the dependency would create a cycle", ErrInvalidInput)
+
+	ErrInvalidLinkType   = fmt.Errorf("%w: link type must be one of relates_to, duplicate_of, duplicated_by, follow_up_of, followed_up_by", ErrInvalidInput)
+	ErrUnknownLinkedTodo = fmt.Errorf("%w: linked todo does not exist", ErrInvalidInput)
+	ErrSelfLink          = fmt.Errorf("%w: a todo cannot be linked to itself", ErrInvalidInput)
+	ErrDuplicateCycle    = fmt.Errorf("%w: the todos would be duplicates of each other", ErrInvalidInput)
+
+	ErrInvalidRecurrence  = fmt.Errorf("%w: recurrence must be an RRULE using FREQ, INTERVAL, BYDAY, COUNT and UNTIL", ErrInvalidInput)
+	ErrRecurrenceNeedsDue = fmt.Errorf("%w: a recurring todo needs a due date", ErrInvalidInput)
+	ErrNotRecurring       = fmt.Errorf("%w: todo does not recur", ErrInvalidInput)
+	ErrInvalidTimezone    = fmt.Errorf("%w: recurrence_timezone must be an IANA time zone such as Europe/Berlin", ErrInvalidInput)
+
+	ErrInvalidCommentAuthor = fmt.Errorf("%w: comment author must be between 1 and 100 characters", ErrInvalidInput)
+	ErrEmptyComment         = fmt.Errorf("%w: comment body must not be empty", ErrInvalidInput)
+
+	ErrInvalidReminder       = fmt.Errorf("%w: a reminder needs either a valid remind_at or offset_minutes", ErrInvalidInput)
+	ErrInvalidReminderOffset = fmt.Errorf("%w: offset_minutes must not be negative", ErrInvalidInput)
+	ErrReminderNeedsDue      = fmt.Errorf("%w: an offset reminder needs a todo with a due date", ErrInvalidInput)
+
+	ErrTagExists         = fmt.Errorf("%w: a tag with this name already exists", ErrConflict)
+	ErrProjectNotEmpty   = fmt.Errorf("%w: project still has todos, delete with cascade=true to remove them", ErrConflict)
+	ErrRecurrenceEnded   = fmt.Errorf("%w: the recurrence has no further occurrences", ErrConflict)
+	ErrOccurrenceDone    = fmt.Errorf("%w: this occurrence is already completed", ErrConflict)
+	ErrTodoBlocked       = fmt.Errorf("%w: todo is blocked by open todos", ErrConflict)
+	ErrTimerRunning      = fmt.Errorf("%w: user already has a running timer", ErrConflict)
+	ErrNoTimerRunning    = fmt.Errorf("%w: user has no running timer on this todo", ErrConflict)
+	ErrInvalidTransition = fmt.Errorf("%w: the workflow does not allow this status change", ErrConflict)
+	ErrLinkExists        = fmt.Errorf("%w: the todos are already linked", ErrConflict)
+	ErrAlreadyDuplicate  = fmt.Errorf("%w: todo is already marked as a duplicate", ErrConflict)
+	ErrParentTrashed     = fmt.Errorf("%w: the parent todo is in the trash, restore it first", ErrConflict)
+
+	ErrAttachmentTooLarge = fmt.Errorf("%w: attachment exceeds the maximum file size", ErrTooLarge)
+)
@@ -0,0 +1,98 @@
+package domain
+
+import "time"
+
+// DueFilter selects todos relative to their due date.
+type DueFilter string
+
+const (
+	DueAny     DueFilter = ""
+	DueOverdue DueFilter = "overdue"
+	DueToday   DueFilter = "today"
+)
+
+// TagMatch decides whether a todo needs any or all of the filtered tags.
+// Without one, any tag will do.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any"
+	TagMatchAll TagMatch = "all"
+)
+
+// ArchivedFilter decides whether archived todos are listed.
+type ArchivedFilter string
+
+const (
+	ArchivedExclude ArchivedFilter = ""
+	ArchivedInclude ArchivedFilter = "include"
+	ArchivedOnly    ArchivedFilter = "only"
+)
+
+// MaxSortKeys is the largest number of keys a sort order may have.
+const MaxSortKeys = 5
+
+// SortKey is one key of the order of the todos returned by GetAll: either
+// a sortable Field of todos, or the CustomField with this key of the
+// filtered project, whose type is resolved into CustomFieldType. Todos
+// without a value come last in either direction.
+type SortKey struct {
+	Field           string
+	CustomField     string
+	Desc            bool
+	CustomFieldType CustomFieldType
+}
+
+// CustomFieldCondition matches todos whose custom field Key equals Value.
+// Value holds the typed value, e.g. a float64 for number fields.
+type CustomFieldCondition struct {
+	Key   string
+	Value any
+}
+
+// TodoFilter narrows down the todos returned by GetAll.
+//
+// Query holds a query in the todo query language, which the usecase parses
+// into the other fields with ParseTodoQuery, including SearchTerms for its
+// text and DueAfter/DueBefore for due date ranges.
+// Due, DueWithinDays and Location come from the caller; the usecase
+// resolves them into the DueAfter/DueBefore range the repository applies.
+// Actionable keeps only open todos that are not blocked by open todos.
+// Title and Description keep todos containing the text, ignoring case. The
+// After/Before ranges include their start and exclude their end.
+// CustomFields holds the raw values of custom field filters by key; since
+// their types depend on the project, they need a ProjectID and are resolved
+// into CustomFieldConditions. The same goes for custom fields in Sort.
+// Without Sort, todos are listed in their manual order.
+type TodoFilter struct {
+	ProjectID       *uint
+	Statuses        []Status
+	Due             DueFilter
+	DueWithinDays   int
+	Location        *time.Location
+	Priorities      []Priority
+	MinPriority     Priority
+	Tags            []string
+	TagMatch        TagMatch
+	Sort            []SortKey
+	Actionable      bool
+	Archived        ArchivedFilter
+	Completed       *bool
+	CompletedAfter  *time.Time
+	CompletedBefore *time.Time
+	Title           string
+	Description     string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	UpdatedAfter    *time.Time
+	UpdatedBefore   *time.Time
+	IDs             []uint
+	CustomFields    map[string]string
+	Query           string
+
+	DueAfter              *time.Time
+	DueBefore             *time.Time
+	OnlyOpen              bool
+	CustomFieldConditions []CustomFieldCondition
+	SearchTerms           []SearchTerm
+}
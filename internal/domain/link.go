@@ -0,0 +1,40 @@
+package domain
+
+// LinkType is the relation of a todo to a linked todo. Every type has an
+// inverse that describes the relation from the other side.
+type LinkType string
+
+const (
+	LinkRelatesTo    LinkType = "relates_to"
+	LinkDuplicateOf  LinkType = "duplicate_of"
+	LinkDuplicatedBy LinkType = "duplicated_by"
+	LinkFollowUpOf   LinkType = "follow_up_of"
+	LinkFollowedUpBy LinkType = "followed_up_by"
+)
+
+// Inverse returns the type of the same link seen from the linked todo.
+func (t LinkType) Inverse() (LinkType, bool) {
+	switch t {
+	case LinkRelatesTo:
+		return LinkRelatesTo, true
+	case LinkDuplicateOf:
+		return LinkDuplicatedBy, true
+	case LinkDuplicatedBy:
+		return LinkDuplicateOf, true
+	case LinkFollowUpOf:
+		return LinkFollowedUpBy, true
+	case LinkFollowedUpBy:
+		return LinkFollowUpOf, true
+	default:
+		return "", false
+	}
+}
+
+// TodoLink links a todo to the todo TodoID. Title and Status describe the
+// linked todo in responses.
+type TodoLink struct {
+	Type   LinkType `json:"type"`
+	TodoID uint     `json:"todo_id"`
+	Title  string   `json:"title,omitempty"`
+	Status Status   `json:"status,omitempty"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "testing"
+
+func TestLinkTypeInverse(t *testing.T) {
+	tests := []struct {
+		linkType LinkType
+		want     LinkType
+	}{
+		{LinkRelatesTo, LinkRelatesTo},
+		{LinkDuplicateOf, LinkDuplicatedBy},
+		{LinkDuplicatedBy, LinkDuplicateOf},
+		{LinkFollowUpOf, LinkFollowedUpBy},
+		{LinkFollowedUpBy, LinkFollowUpOf},
+	}
+	for _, tt := range tests {
+		got, ok := tt.linkType.Inverse()
+		if !ok || got != tt.want {
+			t.Errorf("%q.Inverse() = %q, %v, want %q", tt.linkType, got, ok, tt.want)
+		}
+		if back, _ := got.Inverse(); back != tt.linkType {
+			t.Errorf("%q.Inverse() = %q, want %q", got, back, tt.linkType)
+		}
+	}
+	for _, linkType := range []LinkType{"", "blocks", "Relates_To"} {
+		if got, ok := linkType.Inverse(); ok {
+			t.Errorf("%q.Inverse() = %q, want no inverse", linkType, got)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+// MarkdownRenderer turns the Markdown of descriptions into sanitized HTML
+// that clients can embed in a page as is.
+type MarkdownRenderer interface {
+	// References returns the IDs of the todos that source refers to as
+	// #123.
+	References(source string) []uint
+	// Render converts source to HTML. A #123 reference becomes a link to
+	// the todo if exists reports true for its ID and stays text otherwise.
+	Render(source string, exists func(id uint) bool) (string, error)
+}
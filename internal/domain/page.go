@@ -0,0 +1,25 @@
+package domain
+
+const (
+	// DefaultPageLimit is the number of todos on a page when no limit is
+	// given.
+	DefaultPageLimit = 50
+	// MaxPageLimit is the largest page that can be requested.
+	MaxPageLimit = 200
+)
+
+// PageRequest asks for up to Limit todos following Cursor. Cursor is empty
+// for the first page and otherwise the NextCursor of the previous page; it
+// is opaque to clients and only valid for the sort order it was created
+// with.
+type PageRequest struct {
+	Cursor string
+	Limit  int
+}
+
+// TodoPage is one page of a todo list. NextCursor is empty on the last
+// page.
+type TodoPage struct {
+	Todos      []*Todo `json:"todos"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
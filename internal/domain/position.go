@@ -0,0 +1,90 @@
+package domain
+
+import "strings"
+
+// positionDigits are the digits of positions, in ascending byte order.
+// Positions are fractions in base 62 written without the leading "0.", so
+// comparing them byte by byte (COLLATE "C") orders them numerically as long
+// as none ends in "0".
+const positionDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MaxPositionLength is the length beyond which positions should be
+// rebalanced rather than grown further.
+const MaxPositionLength = 50
+
+// TodoMove places a todo directly before or after another todo in the
+// manual order. Exactly one of Before and After is set.
+type TodoMove struct {
+	Before *uint `json:"before,omitempty"`
+	After  *uint `json:"after,omitempty"`
+}
+
+// PositionBetween returns a position that sorts strictly between a and b.
+// An empty a stands for the start of the list and an empty b for its end.
+// It reports false if there is no such position, i.e. if a does not sort
+// before b.
+func PositionBetween(a, b string) (string, bool) {
+	if b != "" && a >= b {
+		return "", false
+	}
+
+	base := len(positionDigits)
+	var position []byte
+	limit := len(a) + len(b) + 1
+	for i := 0; i < limit; i++ {
+		lo := positionDigit(a, i)
+		hi := base
+		if b != "" {
+			hi = positionDigit(b, i)
+		}
+		switch {
+		case lo == hi:
+			position = append(position, positionDigits[lo])
+		case hi-lo > 1:
+			position = append(position, positionDigits[(lo+hi)/2])
+			return string(position), true
+		default:
+			// The digits are adjacent: keep a's digit, after which the
+			// position is below b whatever follows, and continue above a.
+			position = append(position, positionDigits[lo])
+			b = ""
+		}
+	}
+	return "", false
+}
+
+// SpreadPositions returns n ascending positions that are evenly spaced and
+// as short as possible, leaving room for insertions between any two.
+func SpreadPositions(n int) []string {
+	base := int64(len(positionDigits))
+	width, capacity := 1, base
+	for capacity < int64(n+1)*base {
+		width++
+		capacity *= base
+	}
+	step := capacity / int64(n+1)
+
+	positions := make([]string, n)
+	digits := make([]byte, width)
+	for i := range positions {
+		v := int64(i+1) * step
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = positionDigits[v%base]
+			v /= base
+		}
+		positions[i] = strings.TrimRight(string(digits), "0")
+	}
+	return positions
+}
+
+// positionDigit returns the value of the i-th digit of position, treating
+// missing digits as zero.
+func positionDigit(position string, i int) int {
+	if i >= len(position) {
+		return 0
+	}
+	if d := strings.IndexByte(positionDigits, position[i]); d > 0 {
+		return d
+	}
+	return 0
+}
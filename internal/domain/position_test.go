@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+// checkBetween fails unless position sorts strictly between a and b, where
+// an empty a or b stands for the start or end of the list, and is valid.
+func checkBetween(t *testing.T, a, b, position string) {
+	t.Helper()
+	switch {
+	case position == "":
+		t.Fatalf("PositionBetween(%q, %q) returned an empty position", a, b)
+	case strings.HasSuffix(position, "0"):
+		t.Fatalf("PositionBetween(%q, %q) = %q, which ends in 0", a, b, position)
+	case strings.Trim(position, positionDigits) != "":
+		t.Fatalf("PositionBetween(%q, %q) = %q, which has invalid digits", a, b, position)
+	case position <= a:
+		t.Fatalf("PositionBetween(%q, %q) = %q, which does not sort after %q", a, b, position, a)
+	case b != "" && position >= b:
+		t.Fatalf("PositionBetween(%q, %q) = %q, which does not sort before %q", a, b, position, b)
+	}
+}
+
+func TestPositionBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{name: "empty list", a: "", b: "", want: "V"},
+		{name: "front", a: "", b: "V", want: "F"},
+		{name: "front of the smallest digit", a: "", b: "1", want: "0V"},
+		{name: "front of a leading zero", a: "", b: "01", want: "00V"},
+		{name: "end", a: "V", b: "", want: "k"},
+		{name: "end after the largest digit", a: "z", b: "", want: "zV"},
+		{name: "between", a: "A", b: "K", want: "F"},
+		{name: "between adjacent digits", a: "A", b: "B", want: "AV"},
+		{name: "between a prefix and its extension", a: "A", b: "A1", want: "A0V"},
+		{name: "between longer and shorter", a: "Az", b: "B", want: "AzV"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PositionBetween(tt.a, tt.b)
+			if !ok {
+				t.Fatalf("PositionBetween(%q, %q) reported no position", tt.a, tt.b)
+			}
+			checkBetween(t, tt.a, tt.b, got)
+			if got != tt.want {
+				t.Errorf("PositionBetween(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPositionBetweenWithoutRoom(t *testing.T) {
+	for _, tt := range []struct{ a, b string }{
+		{"V", "V"},
+		{"W", "V"},
+		{"V1", "V"},
+	} {
+		if got, ok := PositionBetween(tt.a, tt.b); ok {
+			t.Errorf("PositionBetween(%q, %q) = %q, want no position", tt.a, tt.b, got)
+		}
+	}
+}
+
+// TestPositionGrowth inserts repeatedly at the same place and checks that
+// positions stay ordered while they grow, until they pass
+// MaxPositionLength, at which point callers rebalance.
+func TestPositionGrowth(t *testing.T) {
+	tests := []struct {
+		name   string
+		insert func(first, last string) (a, b string)
+	}{
+		{name: "at the front", insert: func(first, last string) (string, string) { return "", first }},
+		{name: "at the end", insert: func(first, last string) (string, string) { return last, "" }},
+		{name: "behind the first", insert: func(first, last string) (string, string) { return first, last }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last := "F", "k"
+			for i := 0; ; i++ {
+				a, b := tt.insert(first, last)
+				position, ok := PositionBetween(a, b)
+				if !ok {
+					t.Fatalf("insert %d: PositionBetween(%q, %q) reported no position", i, a, b)
+				}
+				checkBetween(t, a, b, position)
+				if len(position) > MaxPositionLength {
+					return
+				}
+				if i > 10000 {
+					t.Fatalf("positions did not grow beyond %d characters", MaxPositionLength)
+				}
+				switch {
+				case a == "":
+					first = position
+				case b == "":
+					last = position
+				default:
+					last = position
+				}
+			}
+		})
+	}
+}
+
+func TestSpreadPositions(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 61, 62, 1000, 5000} {
+		positions := SpreadPositions(n)
+		if len(positions) != n {
+			t.Fatalf("SpreadPositions(%d) returned %d positions", n, len(positions))
+		}
+		for i, position := range positions {
+			if position == "" || strings.HasSuffix(position, "0") {
+				t.Fatalf("SpreadPositions(%d)[%d] = %q is not a valid position", n, i, position)
+			}
+			if i > 0 {
+				if position <= positions[i-1] {
+					t.Fatalf("SpreadPositions(%d) is not ascending at %d: %q after %q", n, i, position, positions[i-1])
+				}
+				if between, ok := PositionBetween(positions[i-1], position); !ok || len(between) > len(position)+1 {
+					t.Fatalf("SpreadPositions(%d) leaves no room between %q and %q", n, positions[i-1], position)
+				}
+			}
+		}
+		// One digit more than needed to tell the positions apart leaves a
+		// whole digit of room between neighbours.
+		if n > 0 && n < 62 && len(positions[n-1]) > 2 {
+			t.Errorf("SpreadPositions(%d) uses %d digits, want at most 2", n, len(positions[n-1]))
+		}
+	}
+}
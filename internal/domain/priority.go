@@ -0,0 +1,41 @@
+package domain
+
+// Priority expresses how urgent a todo is.
+type Priority string
+
+const (
+	PriorityNone   Priority = "none"
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+// priorities lists every priority ordered from least to most urgent, so the
+// index of a priority is its rank.
+var priorities = []Priority{
+	PriorityNone,
+	PriorityLow,
+	PriorityMedium,
+	PriorityHigh,
+	PriorityUrgent,
+}
+
+// Rank returns the position of p on the urgency scale, or false when p is
+// not a known priority.
+func (p Priority) Rank() (int, bool) {
+	for i, known := range priorities {
+		if p == known {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// PriorityFromRank is the inverse of Rank. Unknown ranks map to PriorityNone.
+func PriorityFromRank(rank int) Priority {
+	if rank < 0 || rank >= len(priorities) {
+		return PriorityNone
+	}
+	return priorities[rank]
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "testing"
+
+func TestPriorityRank(t *testing.T) {
+	tests := []struct {
+		priority Priority
+		rank     int
+		ok       bool
+	}{
+		{PriorityNone, 0, true},
+		{PriorityLow, 1, true},
+		{PriorityMedium, 2, true},
+		{PriorityHigh, 3, true},
+		{PriorityUrgent, 4, true},
+		{"", 0, false},
+		{"High", 0, false},
+		{"critical", 0, false},
+	}
+	for _, tt := range tests {
+		rank, ok := tt.priority.Rank()
+		if rank != tt.rank || ok != tt.ok {
+			t.Errorf("Priority(%q).Rank() = %d, %v, want %d, %v", tt.priority, rank, ok, tt.rank, tt.ok)
+		}
+		if ok {
+			if got := PriorityFromRank(rank); got != tt.priority {
+				t.Errorf("PriorityFromRank(%d) = %q, want %q", rank, got, tt.priority)
+			}
+		}
+	}
+}
+
+func TestPriorityFromUnknownRank(t *testing.T) {
+	for _, rank := range []int{-1, 5, 100} {
+		if got := PriorityFromRank(rank); got != PriorityNone {
+			t.Errorf("PriorityFromRank(%d) = %q, want %q", rank, got, PriorityNone)
+		}
+	}
+}
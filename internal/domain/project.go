@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+type Project struct {
+	ID           uint                    `json:"id"`
+	Name         string                  `json:"name"`
+	Description  string                  `json:"description"`
+	CustomFields []CustomFieldDefinition `json:"custom_fields"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+type ProjectRepository interface {
+	Create(project *Project) error
+	GetByID(id uint) (*Project, error)
+	GetAll() ([]*Project, error)
+	// Update saves a project. Values of custom fields the project no longer
+	// defines are removed from its todos.
+	Update(project *Project) error
+	// Delete removes a project. With cascade its todos are deleted too,
+	// otherwise a project that still has todos is refused.
+	Delete(id uint, cascade bool) error
+}
+
+type ProjectUsecase interface {
+	Create(project *Project) error
+	GetByID(id uint) (*Project, error)
+	GetAll() ([]*Project, error)
+	Update(project *Project) error
+	Delete(id uint, cascade bool) error
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReminderStatus tracks whether a reminder has been delivered.
+type ReminderStatus string
+
+const (
+	ReminderPending ReminderStatus = "pending"
+	ReminderSent    ReminderStatus = "sent"
+	// ReminderSkipped reminders were not sent because their todo was
+	// already completed or deleted for good when they came due.
+	ReminderSkipped ReminderStatus = "skipped"
+	// ReminderMissed reminders came due while the scheduler was down for
+	// so long that sending them would no longer be useful.
+	ReminderMissed ReminderStatus = "missed"
+	// ReminderFailed reminders could not be delivered after several attempts.
+	ReminderFailed ReminderStatus = "failed"
+)
+
+// Reminder fires either at an absolute time (RemindAt) or a number of
+// minutes before the due date of its todo (OffsetMinutes). FireAt is the
+// resulting point in time and follows changes of the due date.
+type Reminder struct {
+	ID            uint           `json:"id"`
+	TodoID        uint           `json:"todo_id"`
+	RemindAt      *time.Time     `json:"remind_at,omitempty"`
+	OffsetMinutes *int           `json:"offset_minutes,omitempty"`
+	FireAt        *time.Time     `json:"fire_at"`
+	Status        ReminderStatus `json:"status"`
+	Attempts      int            `json:"attempts"`
+	NextAttemptAt *time.Time     `json:"-"`
+	LastError     string         `json:"last_error,omitempty"`
+	FiredAt       *time.Time     `json:"fired_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// Notification is what a Notifier delivers when a reminder fires.
+type Notification struct {
+	Reminder Reminder `json:"reminder"`
+	Todo     Todo     `json:"todo"`
+	// Late is set when the reminder is delivered noticeably after its
+	// FireAt, e.g. because the server was down.
+	Late bool `json:"late"`
+}
+
+// Notifier delivers fired reminders to the outside world.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+type ReminderRepository interface {
+	Create(reminder *Reminder) error
+	GetByID(id uint) (*Reminder, error)
+	GetByTodoID(todoID uint) ([]*Reminder, error)
+	Delete(id uint) error
+	// ProcessDue locks the earliest pending reminder due at now, passes it
+	// to handle and stores the changes handle makes, all in one transaction.
+	// If handle fails, the reminder is left as it was and the error is
+	// returned. Concurrent callers never see the same reminder, and
+	// reminders of todos in the trash are not due until the todo is
+	// restored. It reports false when no reminder is due.
+	ProcessDue(now time.Time, handle func(reminder *Reminder) error) (bool, error)
+}
+
+type ReminderUsecase interface {
+	Create(reminder *Reminder) error
+	GetByTodoID(todoID uint) ([]*Reminder, error)
+	Delete(todoID, id uint) error
+	// FireDue delivers every reminder that is due at now and returns how
+	// many reminders it handled.
+	FireDue(ctx context.Context, now time.Time) (int, error)
+}
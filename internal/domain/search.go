@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaxSearchTerms is the largest number of words, phrases and prefixes a
+// search query may have.
+const MaxSearchTerms = 20
+
+// SearchTerm is one part of a full-text search query: a word, a "quoted
+// phrase" whose words have to follow each other, or a prefix* matching
+// every word that starts with it. Exclude keeps todos that do not match the
+// term, written as a leading -.
+type SearchTerm struct {
+	Text    string
+	Phrase  bool
+	Prefix  bool
+	Exclude bool
+}
+
+// SearchQuery asks for the todos matching Text, most relevant first. The
+// usecase parses Text into Terms for the repository.
+type SearchQuery struct {
+	Text      string
+	ProjectID *uint
+	Limit     int
+
+	Terms []SearchTerm
+}
+
+// SearchResult is a todo found by a search. TitleHighlight and Snippet are
+// HTML: the text is escaped and the matches are wrapped in <mark>. Snippet
+// holds the parts of the description around the matches.
+type SearchResult struct {
+	Todo           *Todo   `json:"todo"`
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet,omitempty"`
+}
+
+// ParseSearchTerms splits a search query into its terms. Terms are
+// separated by spaces; a term is a word, a prefix ending in * or a phrase in
+// double quotes, each optionally preceded by - to exclude it.
+func ParseSearchTerms(text string) ([]SearchTerm, error) {
+	var terms []SearchTerm
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		var term SearchTerm
+		if strings.HasPrefix(rest, "-") {
+			term.Exclude = true
+			rest = rest[1:]
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: phrase %s is missing its closing quote", ErrInvalidSearch, rest)
+			}
+			term.Text = strings.TrimSpace(rest[1 : end+1])
+			term.Phrase = true
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			var word string
+			word, rest = rest[:end], rest[end:]
+			if err := term.setWord(word); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidSearch, err)
+			}
+		}
+		rest = strings.TrimSpace(rest)
+
+		if term.Text == "" {
+			continue
+		}
+		terms = append(terms, term)
+	}
+
+	if len(terms) > MaxSearchTerms {
+		return nil, fmt.Errorf("%w: a query can have at most %d terms", ErrInvalidSearch, MaxSearchTerms)
+	}
+	for _, term := range terms {
+		if !term.Exclude {
+			return terms, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: q needs at least one word that is not excluded", ErrInvalidSearch)
+}
+
+// setWord makes the term a word, or a prefix if word ends in *.
+func (t *SearchTerm) setWord(word string) error {
+	t.Text = word
+	if prefix, ok := strings.CutSuffix(word, "*"); ok {
+		if prefix == "" || strings.IndexFunc(prefix, isNotWordChar) >= 0 {
+			return fmt.Errorf("prefix %s must consist of letters and digits only", word)
+		}
+		t.Text = prefix
+		t.Prefix = true
+	}
+	return nil
+}
+
+func isNotWordChar(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSearchTerms(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []SearchTerm
+	}{
+		{
+			name: "words",
+			text: "  release\tnotes  ",
+			want: []SearchTerm{{Text: "release"}, {Text: "notes"}},
+		},
+		{
+			name: "phrase",
+			text: `"release notes" draft`,
+			want: []SearchTerm{{Text: "release notes", Phrase: true}, {Text: "draft"}},
+		},
+		{
+			name: "phrase without a space after it",
+			text: `"release notes"draft`,
+			want: []SearchTerm{{Text: "release notes", Phrase: true}, {Text: "draft"}},
+		},
+		{
+			name: "spaces inside a phrase",
+			text: `"  release notes "`,
+			want: []SearchTerm{{Text: "release notes", Phrase: true}},
+		},
+		{
+			name: "empty phrase",
+			text: `"" release`,
+			want: []SearchTerm{{Text: "release"}},
+		},
+		{
+			name: "exclusion",
+			text: `release -draft -"old plan" -depr*`,
+			want: []SearchTerm{
+				{Text: "release"},
+				{Text: "draft", Exclude: true},
+				{Text: "old plan", Phrase: true, Exclude: true},
+				{Text: "depr", Prefix: true, Exclude: true},
+			},
+		},
+		{
+			name: "lone minus",
+			text: "release -",
+			want: []SearchTerm{{Text: "release"}},
+		},
+		{
+			name: "prefix",
+			text: "deploy* größ*",
+			want: []SearchTerm{{Text: "deploy", Prefix: true}, {Text: "größ", Prefix: true}},
+		},
+		{
+			name: "quote inside a word",
+			text: `it"s`,
+			want: []SearchTerm{{Text: `it"s`}},
+		},
+		{
+			name: "as many terms as allowed",
+			text: strings.Repeat("word ", MaxSearchTerms),
+			want: func() []SearchTerm {
+				terms := make([]SearchTerm, MaxSearchTerms)
+				for i := range terms {
+					terms[i] = SearchTerm{Text: "word"}
+				}
+				return terms
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSearchTerms(tt.text)
+			if err != nil {
+				t.Fatalf("ParseSearchTerms(%q) error = %v", tt.text, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSearchTerms(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchTermsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantMsg string
+	}{
+		{"unclosed phrase", `release "notes`, `phrase "notes is missing its closing quote`},
+		{"bare prefix", "release *", "prefix * must consist of letters and digits only"},
+		{"prefix with punctuation", "re-lease*", "prefix re-lease* must consist of letters and digits only"},
+		{"too many terms", strings.Repeat("word ", MaxSearchTerms+1), "at most 20 terms"},
+		{"too many phrases", strings.Repeat(`"a b" `, MaxSearchTerms+1), "at most 20 terms"},
+		{"only excluded terms", `-draft -"old plan"`, "at least one word that is not excluded"},
+		{"empty", "   ", "at least one word that is not excluded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSearchTerms(tt.text)
+			if !errors.Is(err, ErrInvalidSearch) || !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("ParseSearchTerms(%q) error = %v, want %v", tt.text, err, ErrInvalidSearch)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("ParseSearchTerms(%q) error = %q, want it to contain %q", tt.text, err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type Tag struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TagRepository interface {
+	Create(tag *Tag) error
+	GetByID(id uint) (*Tag, error)
+	GetByIDs(ids []uint) ([]*Tag, error)
+	GetAll() ([]*Tag, error)
+	Update(tag *Tag) error
+	Delete(id uint) error
+}
+
+type TagUsecase interface {
+	Create(tag *Tag) error
+	GetByID(id uint) (*Tag, error)
+	GetAll() ([]*Tag, error)
+	Update(tag *Tag) error
+	Delete(id uint) error
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// TimeEntry is a span of time a user spent on a todo. Entries created by a
+// timer have no EndedAt while the timer is running.
+type TimeEntry struct {
+	ID              uint       `json:"id"`
+	TodoID          uint       `json:"todo_id"`
+	User            string     `json:"user"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationMinutes int        `json:"duration_minutes"`
+	Running         bool       `json:"running"`
+	Manual          bool       `json:"manual"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Duration returns the length of the entry, counting a running entry up
+// to now.
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	if e.EndedAt != nil {
+		return e.EndedAt.Sub(e.StartedAt)
+	}
+	return now.Sub(e.StartedAt)
+}
+
+// TimerRequest names the user who starts or stops a timer.
+type TimerRequest struct {
+	User string `json:"user"`
+}
+
+// TimeReportFilter selects the time entries summed up by a TimeReport.
+// Entries are clipped to the [From, To) range.
+type TimeReportFilter struct {
+	From      time.Time
+	To        time.Time
+	ProjectID *uint
+	User      string
+}
+
+// TimeReport sums tracked time per todo and per project.
+type TimeReport struct {
+	From         time.Time     `json:"from"`
+	To           time.Time     `json:"to"`
+	TotalMinutes int           `json:"total_minutes"`
+	Todos        []TodoTime    `json:"todos"`
+	Projects     []ProjectTime `json:"projects"`
+}
+
+type TodoTime struct {
+	TodoID          uint   `json:"todo_id"`
+	Title           string `json:"title"`
+	ProjectID       *uint  `json:"project_id,omitempty"`
+	EstimateMinutes *int   `json:"estimate_minutes,omitempty"`
+	Minutes         int    `json:"minutes"`
+}
+
+// ProjectTime is the time tracked on the todos of a project. Time on todos
+// without a project is reported with a nil ProjectID.
+type ProjectTime struct {
+	ProjectID *uint  `json:"project_id"`
+	Name      string `json:"name,omitempty"`
+	Minutes   int    `json:"minutes"`
+}
+
+type TimeEntryRepository interface {
+	Create(entry *TimeEntry) error
+	GetByID(id uint) (*TimeEntry, error)
+	GetByTodoID(todoID uint) ([]*TimeEntry, error)
+	// Stop ends the running timer of user on a todo and returns the entry,
+	// or ErrNotFound if there is none.
+	Stop(todoID uint, user string, endedAt time.Time) (*TimeEntry, error)
+	Delete(id uint) error
+	Report(filter TimeReportFilter) (*TimeReport, error)
+}
+
+type TimeEntryUsecase interface {
+	// StartTimer starts tracking time of user on a todo. A user can have
+	// only one running timer.
+	StartTimer(todoID uint, user string) (*TimeEntry, error)
+	// StopTimer ends the running timer of user on a todo, which may be in
+	// the trash.
+	StopTimer(todoID uint, user string) (*TimeEntry, error)
+	// Create records a manual, already finished entry.
+	Create(entry *TimeEntry) error
+	GetByTodoID(todoID uint) ([]*TimeEntry, error)
+	Delete(todoID, id uint) error
+	Report(filter TimeReportFilter) (*TimeReport, error)
+}
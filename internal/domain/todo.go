@@ -3,26 +3,195 @@ package domain
 import "time"
 
 type Todo struct {
-	ID          uint      `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Completed   bool      `json:"completed"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                    uint            `json:"id"`
+	Title                 string          `json:"title"`
+	Description           string          `json:"description"`
+	DescriptionHTML       string          `json:"description_html,omitempty"`
+	Status                Status          `json:"status"`
+	Completed             bool            `json:"completed"`
+	CompletedAt           *time.Time      `json:"completed_at,omitempty"`
+	Archived              bool            `json:"archived"`
+	ArchivedAt            *time.Time      `json:"archived_at,omitempty"`
+	Priority              Priority        `json:"priority"`
+	DueAt                 *time.Time      `json:"due_at,omitempty"`
+	Overdue               bool            `json:"overdue"`
+	Tags                  []Tag           `json:"tags"`
+	ProjectID             *uint           `json:"project_id,omitempty"`
+	ParentID              *uint           `json:"parent_id,omitempty"`
+	Progress              *Progress       `json:"progress,omitempty"`
+	Blocked               bool            `json:"blocked"`
+	Checklist             []ChecklistItem `json:"checklist"`
+	ChecklistAutoComplete bool            `json:"checklist_auto_complete"`
+	Position              string          `json:"position"`
+	EstimateMinutes       *int            `json:"estimate_minutes,omitempty"`
+	TrackedMinutes        int             `json:"tracked_minutes"`
+	CustomFields          map[string]any  `json:"custom_fields"`
+	Links                 []TodoLink      `json:"links,omitempty"`
+	CanonicalID           *uint           `json:"canonical_id,omitempty"`
+	Recurrence            string          `json:"recurrence,omitempty"`
+	RecurrenceTimezone    string          `json:"recurrence_timezone,omitempty"`
+	Occurrence            int             `json:"occurrence,omitempty"`
+	NextOccurrenceID      *uint           `json:"next_occurrence_id,omitempty"`
+	CommentCount          int             `json:"comment_count"`
+	CreatedAt             time.Time       `json:"created_at"`
+	UpdatedAt             time.Time       `json:"updated_at"`
+	DeletedAt             *time.Time      `json:"deleted_at,omitempty"`
 }
 
+// IsOverdue reports whether the todo is still open after its due date.
+func (t *Todo) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && !t.Completed && t.DueAt.Before(now)
+}
+
+// Progress summarizes how many direct subtasks of a todo are done. Subtasks
+// closed without being done, such as cancelled ones, do not count.
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ChildPolicy decides what happens to the subtasks of a deleted todo.
+type ChildPolicy string
+
+const (
+	// ChildrenReparent moves the subtasks up to the deleted todo's parent.
+	ChildrenReparent ChildPolicy = "reparent"
+	// ChildrenCascade deletes the whole subtree.
+	ChildrenCascade ChildPolicy = "cascade"
+)
+
 type TodoRepository interface {
 	Create(todo *Todo) error
 	GetByID(id uint) (*Todo, error)
-	GetAll() ([]*Todo, error)
+	// GetAll returns the page of todos matching filter that follows
+	// page.Cursor, ordered as filter.Sort asks. The order is total, so
+	// todos inserted between requests never shift the following pages.
+	GetAll(filter TodoFilter, page PageRequest) (*TodoPage, error)
+	GetChildren(id uint) ([]*Todo, error)
+	// Search returns the todos matching query.Terms, most relevant first.
+	Search(query SearchQuery) ([]*SearchResult, error)
+	// SubtreeHeight returns the number of levels below the todo, 0 for a leaf.
+	SubtreeHeight(id uint) (int, error)
 	Update(todo *Todo) error
-	Delete(id uint) error
+	// CompleteOccurrence saves a completed occurrence of a recurring todo
+	// and creates the next occurrence atomically.
+	CompleteOccurrence(todo, next *Todo) error
+	// Delete moves a todo to the trash.
+	Delete(id uint, children ChildPolicy) error
+	// GetTrash returns the deleted todos, most recently deleted first.
+	GetTrash() ([]*Todo, error)
+	// Restore brings back a deleted todo together with the subtasks that
+	// were deleted along with it.
+	Restore(id uint) error
+	// Purge permanently removes a deleted todo and the subtasks that were
+	// deleted along with it.
+	Purge(id uint) error
+	// PurgeDeletedBefore permanently removes the todos deleted before the
+	// given time and returns how many were removed.
+	PurgeDeletedBefore(before time.Time) (int, error)
+	// LastPosition returns the highest position in use, or "" if there
+	// are no todos.
+	LastPosition() (string, error)
+	// AdjacentPositions returns the position of the todo targetID and of
+	// its neighbour in the manual order, the todo directly after it if
+	// after is set and directly before it otherwise. excludeID is skipped
+	// when looking for the neighbour; neighbour is "" at either end.
+	AdjacentPositions(targetID, excludeID uint, after bool) (target, neighbour string, err error)
+	SetPosition(id uint, position string) error
+	// RebalancePositions gives all todos short, evenly spaced positions
+	// without changing their order.
+	RebalancePositions() error
+	// UpdateChecklist replaces the checklist of a todo with the result of
+	// update, which receives the currently stored checklist.
+	UpdateChecklist(id uint, update func(checklist []ChecklistItem) ([]ChecklistItem, error)) error
+	// GetBlockers returns the todos that directly block the given todo.
+	GetBlockers(id uint) ([]*Todo, error)
+	AddBlocker(id, blockerID uint) error
+	RemoveBlocker(id, blockerID uint) error
+	// DependsOn reports whether id is blocked by otherID, directly or
+	// through other todos.
+	DependsOn(id, otherID uint) (bool, error)
+	// GetHistory returns the completion history of a todo, oldest first.
+	GetHistory(id uint) ([]*TodoEvent, error)
+	// GetLinks returns the links of a todo to todos that are not deleted.
+	GetLinks(id uint) ([]TodoLink, error)
+	// AddLink links id to link.TodoID and link.TodoID back to id with the
+	// inverse type and, unless closed is nil, saves closed in the same
+	// transaction. Links to deleted todos count as well: it fails with
+	// ErrLinkExists if the todos are linked already and with
+	// ErrAlreadyDuplicate if the link would make a duplicate the duplicate of
+	// a second todo.
+	AddLink(id uint, link TodoLink, closed *Todo) error
+	// RemoveLink removes the link between two todos in both directions.
+	RemoveLink(id, linkedID uint) error
+	// CanonicalID follows the duplicate_of links starting at id and returns
+	// the todo at the end, which is id itself if it is not a duplicate.
+	CanonicalID(id uint) (uint, error)
+	// ExistingIDs returns those of ids that belong to todos that are not
+	// deleted.
+	ExistingIDs(ids []uint) ([]uint, error)
+	// SetArchived archives a todo at archivedAt, or unarchives it if
+	// archivedAt is nil.
+	SetArchived(id uint, archivedAt *time.Time) error
+	// ArchiveCompleted archives every completed todo of a project that is
+	// not archived yet and returns how many were archived.
+	ArchiveCompleted(projectID uint, archivedAt time.Time) (int, error)
 }
 
 type TodoUsecase interface {
 	Create(todo *Todo) error
 	GetByID(id uint) (*Todo, error)
-	GetAll() ([]*Todo, error)
+	GetAll(filter TodoFilter, page PageRequest) (*TodoPage, error)
+	GetChildren(id uint) ([]*Todo, error)
+	// Search runs a full-text search over the titles and descriptions of
+	// todos that are not deleted.
+	Search(query SearchQuery) ([]*SearchResult, error)
 	Update(todo *Todo) error
-	Delete(id uint) error
-} 
+	// SkipOccurrence moves a recurring todo on to its next occurrence
+	// without completing it.
+	SkipOccurrence(id uint) (*Todo, error)
+	// StopRecurrence turns a recurring todo into a one-off todo.
+	StopRecurrence(id uint) (*Todo, error)
+	// Delete moves a todo to the trash, from where it can be restored
+	// until it is purged.
+	Delete(id uint, children ChildPolicy) error
+	GetTrash() ([]*Todo, error)
+	Restore(id uint) (*Todo, error)
+	// Purge permanently removes a todo from the trash.
+	Purge(id uint) error
+	// PurgeTrash permanently removes the todos deleted before the given
+	// time and returns how many were removed.
+	PurgeTrash(before time.Time) (int, error)
+	// Move places a todo directly before or after another todo in the
+	// manual order.
+	Move(id uint, move TodoMove) (*Todo, error)
+	AddChecklistItem(todoID uint, item *ChecklistItem) (*Todo, error)
+	// ToggleChecklistItem checks or unchecks an item. Checking the last open
+	// item completes a todo that has ChecklistAutoComplete set.
+	ToggleChecklistItem(todoID, itemID uint) (*Todo, error)
+	ReorderChecklist(todoID uint, order ChecklistOrder) (*Todo, error)
+	RemoveChecklistItem(todoID, itemID uint) (*Todo, error)
+	GetBlockers(id uint) ([]*Todo, error)
+	// GetHistory returns when a todo was completed and reopened.
+	GetHistory(id uint) ([]*TodoEvent, error)
+	GetLinks(id uint) ([]TodoLink, error)
+	// AddLink links a todo to another one. Marking a todo as a duplicate
+	// closes it; the returned todo points to the canonical todo.
+	AddLink(id uint, link TodoLink) (*Todo, error)
+	RemoveLink(id, linkedID uint) error
+	// AddBlocker marks a todo as blocked by another one. Dependencies that
+	// would form a cycle are rejected.
+	AddBlocker(dependency *Dependency) error
+	RemoveBlocker(dependency *Dependency) error
+	// Workflow returns the statuses todos can have and how they may change.
+	Workflow() *Workflow
+	// RenderDescriptions sets DescriptionHTML of the todos to their
+	// description rendered from Markdown.
+	RenderDescriptions(todos []*Todo) error
+	// Archive hides a todo from GetAll unless archived todos are requested.
+	Archive(id uint) (*Todo, error)
+	Unarchive(id uint) (*Todo, error)
+	// ArchiveCompleted archives all completed todos of a project and
+	// returns how many were archived.
+	ArchiveCompleted(projectID uint) (int, error)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// TodoEventType tells what happened to a todo.
+type TodoEventType string
+
+const (
+	// TodoEventCompleted records a todo moving to the done state.
+	TodoEventCompleted TodoEventType = "completed"
+	// TodoEventClosed records a todo moving to another closed state, e.g.
+	// when it is cancelled or closed as a duplicate.
+	TodoEventClosed TodoEventType = "closed"
+	// TodoEventReopened records a closed todo moving back to an open state.
+	TodoEventReopened TodoEventType = "reopened"
+)
+
+// TodoEvent is an entry in the completion history of a todo. Status is the
+// status the todo moved to.
+type TodoEvent struct {
+	ID        uint          `json:"id"`
+	TodoID    uint          `json:"todo_id"`
+	Type      TodoEventType `json:"type"`
+	Status    Status        `json:"status"`
+	CreatedAt time.Time     `json:"created_at"`
+}
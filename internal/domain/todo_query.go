@@ -0,0 +1,451 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// queryFilters lists the filters of the todo query language, for the error
+// about an unknown one.
+const queryFilters = "status, tag, priority, project, id, is, due, created, updated, completed, title, description and cf.<key>"
+
+// queryToken is a token of a todo query and the column, counted in
+// characters from 1, at which it starts.
+type queryToken struct {
+	text   string
+	column int
+}
+
+func (t queryToken) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %q at column %d: %s", ErrInvalidQuery, t.text, t.column, fmt.Sprintf(format, args...))
+}
+
+// ParseTodoQuery adds the conditions of a todo query to filter, e.g.
+//
+//	status:open tag:backend due<2026-11-01 "release notes"
+//
+// Tokens are separated by spaces and are either a filter, written as a key,
+// an operator and a value, or text. Values containing spaces are quoted, as
+// in title:"release notes". Text is matched like the words, "phrases" and
+// prefixes* of a search and may be excluded with a leading -.
+//
+// Values go into the same fields as the query parameters; a filter that
+// takes a single value must not be given by both. Dates are read in
+// filter.Location. Errors name the offending token and its column.
+func ParseTodoQuery(query string, filter *TodoFilter, workflow *Workflow) error {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return err
+	}
+	p := queryParser{filter: filter, workflow: workflow, loc: filter.Location}
+	if p.loc == nil {
+		p.loc = time.UTC
+	}
+	for _, token := range tokens {
+		if err := p.apply(token); err != nil {
+			return err
+		}
+	}
+	if len(filter.SearchTerms) > MaxSearchTerms {
+		return fmt.Errorf("%w: a query can have at most %d words, phrases and prefixes", ErrInvalidQuery, MaxSearchTerms)
+	}
+	if p.tagTokens > 1 {
+		if p.tagList != nil {
+			return p.tagList.errorf("tags are either listed in one tag filter, matching any of them, or given in separate tag filters, matching all of them")
+		}
+		if filter.TagMatch == TagMatchAny {
+			return fmt.Errorf("%w: separate tag filters in q require all of the tags, which contradicts tag_match=any", ErrInvalidFilter)
+		}
+		filter.TagMatch = TagMatchAll
+	}
+	return nil
+}
+
+// tokenizeQuery splits a query at spaces outside of double quotes.
+func tokenizeQuery(query string) ([]queryToken, error) {
+	runes := []rune(query)
+	var tokens []queryToken
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+		start, quote := i, -1
+		for ; i < len(runes) && (quote >= 0 || !unicode.IsSpace(runes[i])); i++ {
+			if runes[i] != '"' {
+				continue
+			}
+			if quote < 0 {
+				quote = i
+			} else {
+				quote = -1
+			}
+		}
+		if quote >= 0 {
+			token := queryToken{text: string(runes[start:]), column: start + 1}
+			return nil, token.errorf("the quote at column %d is never closed", quote+1)
+		}
+		tokens = append(tokens, queryToken{text: string(runes[start:i]), column: start + 1})
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	filter   *TodoFilter
+	workflow *Workflow
+	loc      *time.Location
+
+	tagTokens int
+	tagList   *queryToken
+}
+
+// splitQueryFilter splits a filter token into its key, operator and value.
+// It reports false for text, which does not start with a key followed by
+// an operator.
+func splitQueryFilter(text string) (key, op, value string, ok bool) {
+	end := strings.IndexFunc(text, func(r rune) bool {
+		return r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if end <= 0 {
+		return "", "", "", false
+	}
+	key, rest := text[:end], text[end:]
+	for _, op := range []string{"<=", ">=", ":", "<", ">"} {
+		if value, ok := strings.CutPrefix(rest, op); ok {
+			return strings.ToLower(key), op, value, true
+		}
+	}
+	return "", "", "", false
+}
+
+func (p *queryParser) apply(token queryToken) error {
+	body, exclude := strings.CutPrefix(token.text, "-")
+	key, op, value, ok := splitQueryFilter(body)
+	if !ok {
+		return p.applySearchText(token, body, exclude)
+	}
+	if exclude {
+		return token.errorf("filters cannot be excluded with -, only text can")
+	}
+	if strings.HasPrefix(value, `"`) {
+		if len(value) < 2 || !strings.HasSuffix(value, `"`) || strings.Count(value, `"`) != 2 {
+			return token.errorf("a quoted value has to end with its closing quote")
+		}
+		value = value[1 : len(value)-1]
+	} else if strings.Contains(value, `"`) {
+		return token.errorf("quotes have to surround the whole value")
+	}
+	if value == "" {
+		return token.errorf("%s needs a value", key)
+	}
+
+	if cf, ok := strings.CutPrefix(key, "cf."); ok && cf != "" {
+		if op != ":" {
+			return token.errorf("custom fields can only be compared with :")
+		}
+		if _, set := p.filter.CustomFields[cf]; set {
+			return token.errorf("custom field %s is already filtered on", cf)
+		}
+		if p.filter.CustomFields == nil {
+			p.filter.CustomFields = make(map[string]string)
+		}
+		p.filter.CustomFields[cf] = value
+		return nil
+	}
+
+	switch key {
+	case "status":
+		return p.applyStatus(token, op, value)
+	case "tag":
+		return p.applyTag(token, op, value)
+	case "priority":
+		return p.applyPriority(token, op, value)
+	case "project":
+		return p.applyProject(token, op, value)
+	case "id":
+		return p.applyID(token, op, value)
+	case "is":
+		return p.applyIs(token, op, value)
+	case "due":
+		return p.applyDue(token, op, value)
+	case "created":
+		return p.applyRange(token, op, value, &p.filter.CreatedAfter, &p.filter.CreatedBefore)
+	case "updated":
+		return p.applyRange(token, op, value, &p.filter.UpdatedAfter, &p.filter.UpdatedBefore)
+	case "completed":
+		return p.applyRange(token, op, value, &p.filter.CompletedAfter, &p.filter.CompletedBefore)
+	case "title":
+		return p.applyContains(token, key, op, value, &p.filter.Title)
+	case "description":
+		return p.applyContains(token, key, op, value, &p.filter.Description)
+	}
+	return token.errorf("unknown filter %s; filters are %s (quote text containing : < or > to search for it)", key, queryFilters)
+}
+
+// applySearchText adds text that is not a filter as a search term.
+func (p *queryParser) applySearchText(token queryToken, text string, exclude bool) error {
+	term := SearchTerm{Exclude: exclude}
+	if strings.HasPrefix(text, `"`) {
+		if len(text) < 2 || !strings.HasSuffix(text, `"`) || strings.Count(text, `"`) != 2 {
+			return token.errorf("a phrase has to end with its closing quote")
+		}
+		term.Text = strings.TrimSpace(text[1 : len(text)-1])
+		term.Phrase = true
+	} else {
+		if strings.Contains(text, `"`) {
+			return token.errorf("quotes have to surround the whole phrase")
+		}
+		if err := term.setWord(text); err != nil {
+			return token.errorf("%s", err)
+		}
+	}
+	if term.Text == "" {
+		return token.errorf("there is no text to search for")
+	}
+	p.filter.SearchTerms = append(p.filter.SearchTerms, term)
+	return nil
+}
+
+// expectColon rejects the comparison operators for filters that only match
+// values.
+func expectColon(token queryToken, key, op string) error {
+	if op != ":" {
+		return token.errorf("%s can only be matched with :, not %s", key, op)
+	}
+	return nil
+}
+
+// queryList splits a comma separated list of values.
+func queryList(token queryToken, value string) ([]string, error) {
+	values := strings.Split(value, ",")
+	for _, v := range values {
+		if v == "" {
+			return nil, token.errorf("the list has an empty value")
+		}
+	}
+	return values, nil
+}
+
+func (p *queryParser) applyStatus(token queryToken, op, value string) error {
+	if err := expectColon(token, "status", op); err != nil {
+		return err
+	}
+	values, err := queryList(token, value)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		status := Status(v)
+		if _, ok := p.workflow.State(status); !ok {
+			return token.errorf("%s is not a status of the workflow", v)
+		}
+		p.filter.Statuses = append(p.filter.Statuses, status)
+	}
+	return nil
+}
+
+// applyTag adds tags. The tags of one tag filter match any of them, while
+// separate tag filters all have to match; ParseTodoQuery sets TagMatch once
+// all of them are known.
+func (p *queryParser) applyTag(token queryToken, op, value string) error {
+	if err := expectColon(token, "tag", op); err != nil {
+		return err
+	}
+	values, err := queryList(token, value)
+	if err != nil {
+		return err
+	}
+	p.tagTokens++
+	if len(values) > 1 && p.tagList == nil {
+		p.tagList = &token
+	}
+	p.filter.Tags = append(p.filter.Tags, values...)
+	return nil
+}
+
+// applyPriority matches listed priorities with :, and with >= or > the
+// priorities from or above the given one.
+func (p *queryParser) applyPriority(token queryToken, op, value string) error {
+	if op == ":" {
+		values, err := queryList(token, value)
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			priority := Priority(v)
+			if _, ok := priority.Rank(); !ok {
+				return token.errorf("priority %s must be one of none, low, medium, high, urgent", v)
+			}
+			p.filter.Priorities = append(p.filter.Priorities, priority)
+		}
+		return nil
+	}
+	if op != ">=" && op != ">" {
+		return token.errorf("priority can only be compared with :, >= and >, not %s", op)
+	}
+	if p.filter.MinPriority != "" {
+		return token.errorf("a minimum priority is already set")
+	}
+	rank, ok := Priority(value).Rank()
+	if !ok {
+		return token.errorf("priority %s must be one of none, low, medium, high, urgent", value)
+	}
+	if op == ">" {
+		rank++
+		if PriorityFromRank(rank) == PriorityNone {
+			return token.errorf("no priority is above %s", value)
+		}
+	}
+	p.filter.MinPriority = PriorityFromRank(rank)
+	return nil
+}
+
+func (p *queryParser) applyProject(token queryToken, op, value string) error {
+	if err := expectColon(token, "project", op); err != nil {
+		return err
+	}
+	if p.filter.ProjectID != nil {
+		return token.errorf("a project is already set")
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		return token.errorf("%s is not a project ID", value)
+	}
+	projectID := uint(id)
+	p.filter.ProjectID = &projectID
+	return nil
+}
+
+func (p *queryParser) applyID(token queryToken, op, value string) error {
+	if err := expectColon(token, "id", op); err != nil {
+		return err
+	}
+	values, err := queryList(token, value)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || id == 0 {
+			return token.errorf("%s is not a todo ID", v)
+		}
+		p.filter.IDs = append(p.filter.IDs, uint(id))
+	}
+	return nil
+}
+
+// applyIs handles the flags is:open, is:completed, is:archived,
+// is:actionable and is:overdue.
+func (p *queryParser) applyIs(token queryToken, op, value string) error {
+	if err := expectColon(token, "is", op); err != nil {
+		return err
+	}
+	switch value {
+	case "open", "completed":
+		if p.filter.Completed != nil {
+			return token.errorf("whether todos are completed is already set")
+		}
+		completed := value == "completed"
+		p.filter.Completed = &completed
+	case "archived":
+		if p.filter.Archived != ArchivedExclude {
+			return token.errorf("whether archived todos are listed is already set")
+		}
+		p.filter.Archived = ArchivedOnly
+	case "actionable":
+		p.filter.Actionable = true
+	case "overdue":
+		return p.setDue(token, DueOverdue)
+	default:
+		return token.errorf("is must be open, completed, archived, actionable or overdue")
+	}
+	return nil
+}
+
+// applyDue handles due:overdue and due:today as well as due date ranges.
+func (p *queryParser) applyDue(token queryToken, op, value string) error {
+	if op == ":" && (value == string(DueOverdue) || value == string(DueToday)) {
+		return p.setDue(token, DueFilter(value))
+	}
+	if p.filter.Due != DueAny {
+		return token.errorf("a due date range cannot be combined with due:%s", p.filter.Due)
+	}
+	return p.applyRange(token, op, value, &p.filter.DueAfter, &p.filter.DueBefore)
+}
+
+func (p *queryParser) setDue(token queryToken, due DueFilter) error {
+	if p.filter.Due != DueAny {
+		return token.errorf("due:%s is already set", p.filter.Due)
+	}
+	if p.filter.DueAfter != nil || p.filter.DueBefore != nil {
+		return token.errorf("cannot be combined with a due date range")
+	}
+	p.filter.Due = due
+	return nil
+}
+
+// applyRange narrows the range between after and before. A date stands for
+// the whole day in the parser's location, so due<2026-11-01 ends where that
+// day starts and due<=2026-11-01 where it ends, while due:2026-11-01 is the
+// day itself.
+func (p *queryParser) applyRange(token queryToken, op, value string, after, before **time.Time) error {
+	start, end, err := parseQueryTime(value, p.loc)
+	if err != nil {
+		return token.errorf("%s is neither a date like 2026-11-01 nor an RFC 3339 time", value)
+	}
+	var from, until *time.Time
+	switch op {
+	case ":":
+		from, until = &start, &end
+	case ">=":
+		from = &start
+	case ">":
+		from = &end
+	case "<":
+		until = &start
+	case "<=":
+		until = &end
+	}
+	if from != nil {
+		if *after != nil {
+			return token.errorf("the start of this range is already set")
+		}
+		*after = from
+	}
+	if until != nil {
+		if *before != nil {
+			return token.errorf("the end of this range is already set")
+		}
+		*before = until
+	}
+	return nil
+}
+
+// parseQueryTime reads a date or an RFC 3339 time and returns the time span
+// it covers: the whole day for a date, and the smallest step the database
+// stores for a time.
+func parseQueryTime(v string, loc *time.Location) (start, end time.Time, err error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, t.Add(time.Microsecond), nil
+	}
+	day, err := time.ParseInLocation(time.DateOnly, v, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
+
+// applyContains sets a case-insensitive substring filter such as title.
+func (p *queryParser) applyContains(token queryToken, key, op, value string, dest *string) error {
+	if err := expectColon(token, key, op); err != nil {
+		return err
+	}
+	if *dest != "" {
+		return token.errorf("%s is already set", key)
+	}
+	*dest = value
+	return nil
+}
@@ -0,0 +1,364 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// describeFilter prints the fields of a filter that queries set, following
+// pointers, so that filters can be compared and differences read.
+func describeFilter(f TodoFilter) string {
+	tm := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	project, completed := "-", "-"
+	if f.ProjectID != nil {
+		project = fmt.Sprint(*f.ProjectID)
+	}
+	if f.Completed != nil {
+		completed = fmt.Sprint(*f.Completed)
+	}
+	return fmt.Sprintf("project=%s statuses=%v priorities=%v min_priority=%q tags=%v tag_match=%q ids=%v "+
+		"completed=%s archived=%q actionable=%v due=%q due_range=[%s,%s) created=[%s,%s) updated=[%s,%s) "+
+		"completed_range=[%s,%s) title=%q description=%q cf=%v search=%+v",
+		project, f.Statuses, f.Priorities, f.MinPriority, f.Tags, f.TagMatch, f.IDs,
+		completed, f.Archived, f.Actionable, f.Due, tm(f.DueAfter), tm(f.DueBefore),
+		tm(f.CreatedAfter), tm(f.CreatedBefore), tm(f.UpdatedAfter), tm(f.UpdatedBefore),
+		tm(f.CompletedAfter), tm(f.CompletedBefore), f.Title, f.Description, f.CustomFields, f.SearchTerms)
+}
+
+func TestParseTodoQuery(t *testing.T) {
+	cet := time.FixedZone("CET", 60*60)
+	at := func(t time.Time) *time.Time { return &t }
+	day := func(month time.Month, d int) *time.Time { return at(time.Date(2026, month, d, 0, 0, 0, 0, cet)) }
+	id := func(id uint) *uint { return &id }
+	yes, no := true, false
+
+	tests := []struct {
+		name  string
+		query string
+		want  TodoFilter
+	}{
+		{
+			name:  "example",
+			query: `status:open tag:backend due<2026-11-01 "release notes"`,
+			want: TodoFilter{
+				Statuses:    []Status{StatusOpen},
+				Tags:        []string{"backend"},
+				DueBefore:   day(time.November, 1),
+				SearchTerms: []SearchTerm{{Text: "release notes", Phrase: true}},
+			},
+		},
+		{
+			name:  "lists",
+			query: "status:open,in_progress priority:high,urgent id:1,5 project:3",
+			want: TodoFilter{
+				ProjectID:  id(3),
+				Statuses:   []Status{StatusOpen, StatusInProgress},
+				Priorities: []Priority{PriorityHigh, PriorityUrgent},
+				IDs:        []uint{1, 5},
+			},
+		},
+		{
+			name:  "minimum priority",
+			query: "priority>=high",
+			want:  TodoFilter{MinPriority: PriorityHigh},
+		},
+		{
+			name:  "priority above",
+			query: "priority>medium",
+			want:  TodoFilter{MinPriority: PriorityHigh},
+		},
+		{
+			name:  "inclusive date range",
+			query: "due>=2026-11-01 due<=2026-11-02",
+			want:  TodoFilter{DueAfter: day(time.November, 1), DueBefore: day(time.November, 3)},
+		},
+		{
+			name:  "exclusive date range",
+			query: "completed>2026-10-01 completed<2026-10-08",
+			want:  TodoFilter{CompletedAfter: day(time.October, 2), CompletedBefore: day(time.October, 8)},
+		},
+		{
+			name:  "single day",
+			query: "created:2026-10-01",
+			want:  TodoFilter{CreatedAfter: day(time.October, 1), CreatedBefore: day(time.October, 2)},
+		},
+		{
+			name:  "timestamps",
+			query: "updated>2026-10-01T12:00:00Z updated<=2026-10-02T12:00:00+02:00",
+			want: TodoFilter{
+				UpdatedAfter:  at(time.Date(2026, 10, 1, 12, 0, 0, 1000, time.UTC)),
+				UpdatedBefore: at(time.Date(2026, 10, 2, 10, 0, 0, 1000, time.UTC)),
+			},
+		},
+		{
+			name:  "flags",
+			query: "is:open is:archived is:actionable is:overdue",
+			want:  TodoFilter{Completed: &no, Archived: ArchivedOnly, Actionable: true, Due: DueOverdue},
+		},
+		{
+			name:  "completed todos due today",
+			query: "is:completed due:today",
+			want:  TodoFilter{Completed: &yes, Due: DueToday},
+		},
+		{
+			name:  "text",
+			query: `deploy* -draft "release notes" -"old plan"`,
+			want: TodoFilter{SearchTerms: []SearchTerm{
+				{Text: "deploy", Prefix: true},
+				{Text: "draft", Exclude: true},
+				{Text: "release notes", Phrase: true},
+				{Text: "old plan", Phrase: true, Exclude: true},
+			}},
+		},
+		{
+			name:  "quoted values",
+			query: `title:"release notes" description:changelog cf.points:3 cf.team:"web ui"`,
+			want: TodoFilter{
+				Title:        "release notes",
+				Description:  "changelog",
+				CustomFields: map[string]string{"points": "3", "team": "web ui"},
+			},
+		},
+		{
+			name:  "separate tags",
+			query: "tag:backend tag:bug",
+			want:  TodoFilter{Tags: []string{"backend", "bug"}, TagMatch: TagMatchAll},
+		},
+		{
+			name:  "tag list",
+			query: "tag:backend,bug",
+			want:  TodoFilter{Tags: []string{"backend", "bug"}},
+		},
+		{
+			name:  "keys ignore case and spaces are collapsed",
+			query: "  Status:open \t  IS:open  ",
+			want:  TodoFilter{Statuses: []Status{StatusOpen}, Completed: &no},
+		},
+		{
+			name:  "empty query",
+			query: "   ",
+			want:  TodoFilter{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := TodoFilter{Location: cet}
+			if err := ParseTodoQuery(tt.query, &filter, DefaultWorkflow()); err != nil {
+				t.Fatalf("ParseTodoQuery(%q) error = %v", tt.query, err)
+			}
+			if got, want := describeFilter(filter), describeFilter(tt.want); got != want {
+				t.Errorf("ParseTodoQuery(%q)\n got %s\nwant %s", tt.query, got, want)
+			}
+		})
+	}
+}
+
+func TestParseTodoQueryErrors(t *testing.T) {
+	id := func(id uint) *uint { return &id }
+	no := false
+
+	tests := []struct {
+		name    string
+		query   string
+		preset  TodoFilter
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "bad date",
+			query:   "status:open due<tomorrow",
+			wantErr: ErrInvalidQuery,
+			wantMsg: `"due<tomorrow" at column 13: tomorrow is neither a date`,
+		},
+		{
+			name:    "column counts characters",
+			query:   "  tëst   status:nope",
+			wantErr: ErrInvalidQuery,
+			wantMsg: `"status:nope" at column 10: nope is not a status of the workflow`,
+		},
+		{
+			name:    "unknown filter",
+			query:   "foo:bar",
+			wantErr: ErrInvalidQuery,
+			wantMsg: `"foo:bar" at column 1: unknown filter foo`,
+		},
+		{
+			name:    "unclosed quoted value",
+			query:   `status:open title:"release notes`,
+			wantErr: ErrInvalidQuery,
+			wantMsg: `at column 13: the quote at column 19 is never closed`,
+		},
+		{
+			name:    "unclosed phrase",
+			query:   `"release notes`,
+			wantErr: ErrInvalidQuery,
+			wantMsg: "at column 1: the quote at column 1 is never closed",
+		},
+		{
+			name:    "quote inside a value",
+			query:   `title:a"b c"`,
+			wantErr: ErrInvalidQuery,
+			wantMsg: "quotes have to surround the whole value",
+		},
+		{
+			name:    "excluded filter",
+			query:   "-status:open",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "filters cannot be excluded",
+		},
+		{
+			name:    "operator not supported",
+			query:   "priority<high",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "priority can only be compared with :, >= and >, not <",
+		},
+		{
+			name:    "no priority above urgent",
+			query:   "priority>urgent",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "no priority is above urgent",
+		},
+		{
+			name:    "comparing a status",
+			query:   "status>open",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "status can only be matched with :, not >",
+		},
+		{
+			name:    "empty list value",
+			query:   "id:1,,2",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "the list has an empty value",
+		},
+		{
+			name:    "missing value",
+			query:   "title:",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "title needs a value",
+		},
+		{
+			name:    "bad prefix",
+			query:   "re-*",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "prefix re-* must consist of letters and digits only",
+		},
+		{
+			name:    "tag list mixed with separate tags",
+			query:   "tag:a,b tag:c",
+			wantErr: ErrInvalidQuery,
+			wantMsg: `"tag:a,b" at column 1: tags are either listed`,
+		},
+		{
+			name:    "project given twice",
+			query:   "project:1 project:2",
+			wantErr: ErrInvalidQuery,
+			wantMsg: `"project:2" at column 11: a project is already set`,
+		},
+		{
+			name:    "range end given twice",
+			query:   "due<2026-11-01 due<=2026-11-02",
+			wantErr: ErrInvalidQuery,
+			wantMsg: "the end of this range is already set",
+		},
+		{
+			name:    "due range after due:today",
+			query:   "due:today due<2026-11-01",
+			wantErr: ErrInvalidQuery,
+			wantMsg: `"due<2026-11-01" at column 11: a due date range cannot be combined with due:today`,
+		},
+		{
+			name:    "too many words",
+			query:   strings.Repeat("word ", MaxSearchTerms+1),
+			wantErr: ErrInvalidQuery,
+			wantMsg: fmt.Sprintf("at most %d words", MaxSearchTerms),
+		},
+		{
+			name:    "project_id parameter",
+			query:   "project:2",
+			preset:  TodoFilter{ProjectID: id(1)},
+			wantErr: ErrInvalidQuery,
+			wantMsg: "a project is already set",
+		},
+		{
+			name:    "completed parameter",
+			query:   "is:completed",
+			preset:  TodoFilter{Completed: &no},
+			wantErr: ErrInvalidQuery,
+			wantMsg: "whether todos are completed is already set",
+		},
+		{
+			name:    "archived parameter",
+			query:   "is:archived",
+			preset:  TodoFilter{Archived: ArchivedInclude},
+			wantErr: ErrInvalidQuery,
+			wantMsg: "whether archived todos are listed is already set",
+		},
+		{
+			name:    "due parameter",
+			query:   "due<2026-11-01",
+			preset:  TodoFilter{Due: DueOverdue},
+			wantErr: ErrInvalidQuery,
+			wantMsg: "a due date range cannot be combined with due:overdue",
+		},
+		{
+			name:    "title parameter",
+			query:   "title:x",
+			preset:  TodoFilter{Title: "y"},
+			wantErr: ErrInvalidQuery,
+			wantMsg: "title is already set",
+		},
+		{
+			name:    "custom field parameter",
+			query:   "cf.points:3",
+			preset:  TodoFilter{CustomFields: map[string]string{"points": "5"}},
+			wantErr: ErrInvalidQuery,
+			wantMsg: "custom field points is already filtered on",
+		},
+		{
+			name:    "tag_match=any parameter",
+			query:   "tag:a tag:b",
+			preset:  TodoFilter{TagMatch: TagMatchAny},
+			wantErr: ErrInvalidFilter,
+			wantMsg: "contradicts tag_match=any",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.preset
+			err := ParseTodoQuery(tt.query, &filter, DefaultWorkflow())
+			if !errors.Is(err, tt.wantErr) || !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("ParseTodoQuery(%q) error = %v, want %v", tt.query, err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("ParseTodoQuery(%q) error = %q, want it to contain %q", tt.query, err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseTodoQueryKeepsParameters(t *testing.T) {
+	filter := TodoFilter{
+		Statuses: []Status{StatusBlocked},
+		Tags:     []string{"web"},
+		TagMatch: TagMatchAll,
+	}
+	if err := ParseTodoQuery("status:open tag:a tag:b", &filter, DefaultWorkflow()); err != nil {
+		t.Fatal(err)
+	}
+	want := TodoFilter{
+		Statuses: []Status{StatusBlocked, StatusOpen},
+		Tags:     []string{"web", "a", "b"},
+		TagMatch: TagMatchAll,
+	}
+	if got, want := describeFilter(filter), describeFilter(want); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
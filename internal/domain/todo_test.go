@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Second), now.Add(time.Second)
+
+	tests := []struct {
+		name string
+		todo Todo
+		want bool
+	}{
+		{"no due date", Todo{}, false},
+		{"due in the past", Todo{DueAt: &before}, true},
+		{"due now", Todo{DueAt: &now}, false},
+		{"due in the future", Todo{DueAt: &after}, false},
+		{"completed after its due date", Todo{DueAt: &before, Completed: true}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.todo.IsOverdue(now); got != tt.want {
+			t.Errorf("%s: IsOverdue() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
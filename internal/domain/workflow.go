@@ -0,0 +1,122 @@
+package domain
+
+import "fmt"
+
+// Status is the state of a todo in the workflow.
+type Status string
+
+const (
+	StatusOpen       Status = "open"
+	StatusInProgress Status = "in_progress"
+	StatusInReview   Status = "in_review"
+	StatusBlocked    Status = "blocked"
+	StatusDone       Status = "done"
+	StatusCancelled  Status = "cancelled"
+)
+
+// WorkflowState is a status a todo can have. A todo in a closed state counts
+// as completed. Transitions lists the states a todo may move on to.
+type WorkflowState struct {
+	Name        Status   `json:"name"`
+	Closed      bool     `json:"closed"`
+	Transitions []Status `json:"transitions"`
+}
+
+// Workflow defines the statuses of todos and how they may change. New todos
+// start in Initial; completing a todo moves it to Done and marking it as a
+// duplicate moves it to Duplicate, or to Done if Duplicate is not set.
+type Workflow struct {
+	Initial   Status          `json:"initial"`
+	Done      Status          `json:"done"`
+	Duplicate Status          `json:"duplicate,omitempty"`
+	States    []WorkflowState `json:"states"`
+}
+
+// DefaultWorkflow returns the workflow used unless another one is configured.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Initial:   StatusOpen,
+		Done:      StatusDone,
+		Duplicate: StatusCancelled,
+		States: []WorkflowState{
+			{Name: StatusOpen, Transitions: []Status{StatusInProgress, StatusBlocked, StatusDone, StatusCancelled}},
+			{Name: StatusInProgress, Transitions: []Status{StatusOpen, StatusInReview, StatusBlocked, StatusDone, StatusCancelled}},
+			{Name: StatusInReview, Transitions: []Status{StatusInProgress, StatusDone, StatusCancelled}},
+			{Name: StatusBlocked, Transitions: []Status{StatusOpen, StatusInProgress, StatusCancelled}},
+			{Name: StatusDone, Closed: true, Transitions: []Status{StatusOpen}},
+			{Name: StatusCancelled, Closed: true, Transitions: []Status{StatusOpen}},
+		},
+	}
+}
+
+// State returns the definition of status.
+func (w *Workflow) State(status Status) (WorkflowState, bool) {
+	for _, state := range w.States {
+		if state.Name == status {
+			return state, true
+		}
+	}
+	return WorkflowState{}, false
+}
+
+// DuplicateStatus returns the status of todos marked as duplicates.
+func (w *Workflow) DuplicateStatus() Status {
+	if w.Duplicate == "" {
+		return w.Done
+	}
+	return w.Duplicate
+}
+
+// IsClosed reports whether status is a closed state.
+func (w *Workflow) IsClosed(status Status) bool {
+	state, ok := w.State(status)
+	return ok && state.Closed
+}
+
+// CanTransition reports whether a todo may move from one status to another.
+// Keeping the current status is always allowed.
+func (w *Workflow) CanTransition(from, to Status) bool {
+	if from == to {
+		return true
+	}
+	state, ok := w.State(from)
+	if !ok {
+		return false
+	}
+	for _, next := range state.Transitions {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the states are unique, that transitions only lead to
+// known states and that Initial is an open and Done and Duplicate are closed
+// states.
+func (w *Workflow) Validate() error {
+	seen := make(map[Status]bool, len(w.States))
+	for _, state := range w.States {
+		if state.Name == "" || seen[state.Name] {
+			return fmt.Errorf("%w: state names must be unique and not empty", ErrInvalidWorkflow)
+		}
+		seen[state.Name] = true
+	}
+	for _, state := range w.States {
+		for _, next := range state.Transitions {
+			if !seen[next] {
+				return fmt.Errorf("%w: state %q moves on to unknown state %q", ErrInvalidWorkflow, state.Name, next)
+			}
+		}
+	}
+	if initial, ok := w.State(w.Initial); !ok || initial.Closed {
+		return fmt.Errorf("%w: initial state %q must be an open state", ErrInvalidWorkflow, w.Initial)
+	}
+	if !w.IsClosed(w.Done) {
+		return fmt.Errorf("%w: done state %q must be a closed state", ErrInvalidWorkflow, w.Done)
+	}
+	if w.Duplicate != "" && !w.IsClosed(w.Duplicate) {
+		return fmt.Errorf("%w: duplicate state %q must be a closed state", ErrInvalidWorkflow, w.Duplicate)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	w := DefaultWorkflow()
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusOpen, StatusInProgress, true},
+		{StatusOpen, StatusDone, true},
+		{StatusOpen, StatusOpen, true},
+		{StatusOpen, StatusInReview, false},
+		{StatusBlocked, StatusDone, false},
+		{StatusDone, StatusOpen, true},
+		{StatusDone, StatusCancelled, false},
+		{StatusCancelled, StatusDone, false},
+		{"archived", StatusOpen, false},
+		{StatusOpen, "archived", false},
+	}
+	for _, tt := range tests {
+		if got := w.CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestIsClosed(t *testing.T) {
+	w := DefaultWorkflow()
+	for status, want := range map[Status]bool{
+		StatusOpen:       false,
+		StatusInProgress: false,
+		StatusBlocked:    false,
+		StatusDone:       true,
+		StatusCancelled:  true,
+		"archived":       false,
+	} {
+		if got := w.IsClosed(status); got != want {
+			t.Errorf("IsClosed(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	if err := DefaultWorkflow().Validate(); err != nil {
+		t.Fatalf("Validate() of the default workflow error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(w *Workflow)
+	}{
+		{"duplicate state", func(w *Workflow) { w.States = append(w.States, WorkflowState{Name: StatusOpen}) }},
+		{"unnamed state", func(w *Workflow) { w.States = append(w.States, WorkflowState{}) }},
+		{"unknown transition", func(w *Workflow) { w.States[0].Transitions = append(w.States[0].Transitions, "archived") }},
+		{"unknown initial state", func(w *Workflow) { w.Initial = "new" }},
+		{"closed initial state", func(w *Workflow) { w.Initial = StatusDone }},
+		{"open done state", func(w *Workflow) { w.Done = StatusInReview }},
+		{"open duplicate state", func(w *Workflow) { w.Duplicate = StatusBlocked }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := DefaultWorkflow()
+			tt.change(w)
+			if err := w.Validate(); !errors.Is(err, ErrInvalidWorkflow) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalidWorkflow)
+			}
+		})
+	}
+}
+
+func TestDuplicateStatus(t *testing.T) {
+	w := DefaultWorkflow()
+	if got := w.DuplicateStatus(); got != StatusCancelled {
+		t.Errorf("DuplicateStatus() = %q, want %q", got, StatusCancelled)
+	}
+	w.Duplicate = ""
+	if got := w.DuplicateStatus(); got != StatusDone {
+		t.Errorf("DuplicateStatus() without a duplicate state = %q, want %q", got, StatusDone)
+	}
+}
@@ -0,0 +1,183 @@
+// Package markdown renders the Markdown of todo descriptions to HTML.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go-todo-api/internal/domain"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+var existsKey = parser.NewContextKey()
+
+type renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+}
+
+// NewRenderer creates a renderer for GitHub flavored Markdown, including
+// task lists. References to todos link to todoURL, in which {id} is
+// replaced by the ID of the todo.
+//
+// Raw HTML in the source is dropped and the output is sanitized once more,
+// so neither markup nor links like javascript: make it into the HTML.
+func NewRenderer(todoURL string) domain.MarkdownRenderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(
+			parser.WithASTTransformers(util.Prioritized(&todoRefTransformer{url: todoURL}, 999)),
+		),
+	)
+
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^todo-ref$`)).OnElements("a")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return &renderer{
+		markdown: md,
+		policy:   policy,
+	}
+}
+
+func (r *renderer) References(source string) []uint {
+	src := []byte(source)
+	doc := r.markdown.Parser().Parse(text.NewReader(src))
+	var ids []uint
+	seen := make(map[uint]bool)
+	for _, found := range findTodoRefs(doc, src) {
+		for _, ref := range found.refs {
+			if !seen[ref.id] {
+				seen[ref.id] = true
+				ids = append(ids, ref.id)
+			}
+		}
+	}
+	return ids
+}
+
+func (r *renderer) Render(source string, exists func(id uint) bool) (string, error) {
+	ctx := parser.NewContext()
+	ctx.Set(existsKey, exists)
+
+	var buf bytes.Buffer
+	if err := r.markdown.Convert([]byte(source), &buf, parser.WithContext(ctx)); err != nil {
+		return "", err
+	}
+	return r.policy.Sanitize(buf.String()), nil
+}
+
+// todoRef is a #123 reference at [start, stop) in the source.
+type todoRef struct {
+	start, stop int
+	id          uint
+}
+
+// textRefs are the references in the text of a Text node.
+type textRefs struct {
+	node *ast.Text
+	refs []todoRef
+}
+
+// findTodoRefs finds the #123 references in the text of doc. A reference is
+// not preceded by a word character, & (character references like &#123;),
+// /, # or \ and not followed by a word character. Code spans are left out,
+// and so is the text of links and images, since a link cannot hold another.
+func findTodoRefs(doc ast.Node, source []byte) []textRefs {
+	var found []textRefs
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Link, *ast.AutoLink, *ast.Image, *ast.CodeSpan:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			if refs := textTodoRefs(n.Segment, source); len(refs) > 0 {
+				found = append(found, textRefs{node: n, refs: refs})
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return found
+}
+
+// textTodoRefs finds the references within segment. The characters around
+// a reference are read from the source, so text split into several nodes
+// is judged the same as text in one.
+func textTodoRefs(segment text.Segment, source []byte) []todoRef {
+	var refs []todoRef
+	for pos := segment.Start; pos < segment.Stop; pos++ {
+		if source[pos] != '#' {
+			continue
+		}
+		if before, _ := utf8.DecodeLastRune(source[:pos]); pos > 0 && (isWordChar(before) || strings.ContainsRune("&/#\\", before)) {
+			continue
+		}
+		end := pos + 1
+		for end < segment.Stop && source[end] >= '0' && source[end] <= '9' {
+			end++
+		}
+		if end == pos+1 {
+			continue
+		}
+		if after, _ := utf8.DecodeRune(source[end:]); end < len(source) && isWordChar(after) {
+			continue
+		}
+		id, err := strconv.ParseUint(string(source[pos+1:end]), 10, 32)
+		if err != nil || id == 0 {
+			continue
+		}
+		refs = append(refs, todoRef{start: pos, stop: end, id: uint(id)})
+		pos = end - 1
+	}
+	return refs
+}
+
+// todoRefTransformer turns #123 into a link to todo 123, if that todo
+// exists.
+type todoRefTransformer struct {
+	url string
+}
+
+func (t *todoRefTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	exists, _ := pc.Get(existsKey).(func(id uint) bool)
+	if exists == nil {
+		return
+	}
+	for _, found := range findTodoRefs(doc, reader.Source()) {
+		// The node keeps the text after the last link, so that it keeps
+		// its line break as well.
+		node, parent := found.node, found.node.Parent()
+		for _, ref := range found.refs {
+			if !exists(ref.id) {
+				continue
+			}
+			if ref.start > node.Segment.Start {
+				parent.InsertBefore(parent, node, ast.NewTextSegment(node.Segment.WithStop(ref.start)))
+			}
+			link := ast.NewLink()
+			link.Destination = []byte(strings.ReplaceAll(t.url, "{id}", strconv.FormatUint(uint64(ref.id), 10)))
+			link.SetAttributeString("class", []byte("todo-ref"))
+			link.AppendChild(link, ast.NewTextSegment(text.NewSegment(ref.start, ref.stop)))
+			parent.InsertBefore(parent, node, link)
+			node.Segment = node.Segment.WithStart(ref.stop)
+		}
+	}
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
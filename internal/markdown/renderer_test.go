@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderTodoReferences(t *testing.T) {
+	r := NewRenderer("/todos/{id}")
+	exists := func(id uint) bool { return id != 99 }
+
+	tests := []struct {
+		name    string
+		source  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "reference in text",
+			source: "see #12 for details",
+			want:   []string{`see <a href="/todos/12" class="todo-ref" rel="nofollow">#12</a> for details`},
+		},
+		{
+			name:   "references at start and end",
+			source: "#1 and #2",
+			want:   []string{`<a href="/todos/1" class="todo-ref" rel="nofollow">#1</a> and <a href="/todos/2" class="todo-ref" rel="nofollow">#2</a>`},
+		},
+		{
+			name:    "reference in link text",
+			source:  "[link #12](http://e.com)",
+			want:    []string{`<a href="http://e.com" rel="nofollow">link #12</a>`},
+			notWant: []string{"todo-ref"},
+		},
+		{
+			name:    "reference in code span",
+			source:  "run `git log #12` first",
+			want:    []string{"<code>git log #12</code>"},
+			notWant: []string{"todo-ref"},
+		},
+		{
+			name:    "reference in code block",
+			source:  "```\n#12\n```",
+			notWant: []string{"todo-ref"},
+		},
+		{
+			name:    "todo that does not exist",
+			source:  "see #99",
+			want:    []string{"see #99"},
+			notWant: []string{"todo-ref"},
+		},
+		{
+			name:    "not a reference",
+			source:  "issue#12, #12a, &#35;12, a/#12 and \\#12",
+			notWant: []string{"todo-ref"},
+		},
+		{
+			name:   "reference followed by a line break",
+			source: "first #3\nsecond",
+			want:   []string{`first <a href="/todos/3" class="todo-ref" rel="nofollow">#3</a>` + "\nsecond"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := r.Render(tt.source, exists)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("Render(%q) = %q, want it to contain %q", tt.source, html, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(html, notWant) {
+					t.Errorf("Render(%q) = %q, want it not to contain %q", tt.source, html, notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	r := NewRenderer("/todos/{id}")
+
+	tests := []struct {
+		source string
+		want   []uint
+	}{
+		{"see #12 and #3, then #12 again", []uint{12, 3}},
+		{"[link #12](http://e.com) and #4", []uint{4}},
+		{"`#12` and ``code #13``", nil},
+		{"```\n#12\n```\n\n#5", []uint{5}},
+		{"issue#12 #0 #12a &#35;12", nil},
+	}
+	for _, tt := range tests {
+		if got := r.References(tt.source); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("References(%q) = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
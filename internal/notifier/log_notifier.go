@@ -0,0 +1,33 @@
+// Package notifier contains the ways a fired reminder can be delivered.
+package notifier
+
+import (
+	"context"
+	"go-todo-api/internal/domain"
+	"log"
+)
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a notifier that writes reminders to the given
+// logger, or to the standard logger if it is nil.
+func NewLogNotifier(logger *log.Logger) domain.Notifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &logNotifier{
+		logger: logger,
+	}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	late := ""
+	if notification.Late {
+		late = " (late)"
+	}
+	n.logger.Printf("reminder %d%s: todo %d %q",
+		notification.Reminder.ID, late, notification.Todo.ID, notification.Todo.Title)
+	return nil
+}
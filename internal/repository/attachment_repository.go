@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) domain.AttachmentRepository {
+	db.AutoMigrate(&models.Attachment{})
+	return &attachmentRepository{
+		db: db,
+	}
+}
+
+func (r *attachmentRepository) Create(attachment *domain.Attachment) error {
+	dbAttachment := models.AttachmentFromDomain(attachment)
+	if err := r.db.Create(dbAttachment).Error; err != nil {
+		return err
+	}
+	*attachment = *dbAttachment.ToDomain()
+	return nil
+}
+
+func (r *attachmentRepository) GetByID(id uint) (*domain.Attachment, error) {
+	var dbAttachment models.Attachment
+	err := r.db.First(&dbAttachment, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbAttachment.ToDomain(), nil
+}
+
+func (r *attachmentRepository) GetByTodoID(todoID uint) ([]*domain.Attachment, error) {
+	var dbAttachments []models.Attachment
+	err := r.db.Where("todo_id = ?", todoID).Order("created_at").Order("id").Find(&dbAttachments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*domain.Attachment, len(dbAttachments))
+	for i, dbAttachment := range dbAttachments {
+		attachments[i] = dbAttachment.ToDomain()
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Attachment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) domain.CommentRepository {
+	db.AutoMigrate(&models.Comment{})
+	return &commentRepository{
+		db: db,
+	}
+}
+
+func (r *commentRepository) Create(comment *domain.Comment) error {
+	dbComment := models.CommentFromDomain(comment)
+	if err := r.db.Create(dbComment).Error; err != nil {
+		return err
+	}
+	*comment = *dbComment.ToDomain()
+	return nil
+}
+
+func (r *commentRepository) GetByID(id uint) (*domain.Comment, error) {
+	var dbComment models.Comment
+	err := r.db.First(&dbComment, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbComment.ToDomain(), nil
+}
+
+func (r *commentRepository) GetByTodoID(todoID uint) ([]*domain.Comment, error) {
+	var dbComments []models.Comment
+	err := r.db.Where("todo_id = ?", todoID).Order("created_at").Order("id").Find(&dbComments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*domain.Comment, len(dbComments))
+	for i, dbComment := range dbComments {
+		comments[i] = dbComment.ToDomain()
+	}
+	return comments, nil
+}
+
+// Update changes the body of a comment. Author and todo are fixed at creation.
+func (r *commentRepository) Update(comment *domain.Comment) error {
+	dbComment := models.CommentFromDomain(comment)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(dbComment).Select("body").Updates(dbComment)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		return tx.First(dbComment, comment.ID).Error
+	})
+	if err != nil {
+		return err
+	}
+	*comment = *dbComment.ToDomain()
+	return nil
+}
+
+func (r *commentRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Comment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
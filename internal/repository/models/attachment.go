@@ -0,0 +1,43 @@
+package models
+
+import (
+	"go-todo-api/internal/domain"
+	"time"
+)
+
+type Attachment struct {
+	ID          uint      `gorm:"primaryKey"`
+	TodoID      uint      `gorm:"not null;index"`
+	Filename    string    `gorm:"size:255;not null"`
+	ContentType string    `gorm:"size:255;not null"`
+	Size        int64     `gorm:"not null"`
+	Checksum    string    `gorm:"size:64;not null"`
+	StorageKey  string    `gorm:"size:255;not null;uniqueIndex"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (a *Attachment) ToDomain() *domain.Attachment {
+	return &domain.Attachment{
+		ID:          a.ID,
+		TodoID:      a.TodoID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		StorageKey:  a.StorageKey,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func AttachmentFromDomain(a *domain.Attachment) *Attachment {
+	return &Attachment{
+		ID:          a.ID,
+		TodoID:      a.TodoID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		StorageKey:  a.StorageKey,
+		CreatedAt:   a.CreatedAt,
+	}
+}
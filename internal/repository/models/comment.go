@@ -0,0 +1,37 @@
+package models
+
+import (
+	"go-todo-api/internal/domain"
+	"time"
+)
+
+type Comment struct {
+	ID        uint      `gorm:"primaryKey"`
+	TodoID    uint      `gorm:"not null;index"`
+	Author    string    `gorm:"size:100;not null"`
+	Body      string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (c *Comment) ToDomain() *domain.Comment {
+	return &domain.Comment{
+		ID:        c.ID,
+		TodoID:    c.TodoID,
+		Author:    c.Author,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func CommentFromDomain(c *domain.Comment) *Comment {
+	return &Comment{
+		ID:        c.ID,
+		TodoID:    c.TodoID,
+		Author:    c.Author,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// TodoDependency records that TodoID cannot be completed before BlockedByID.
+type TodoDependency struct {
+	TodoID      uint      `gorm:"primaryKey"`
+	BlockedByID uint      `gorm:"primaryKey;index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"go-todo-api/internal/domain"
+)
+
+// Checklist stores the checklist items of a todo as a JSONB array.
+type Checklist []domain.ChecklistItem
+
+func (c Checklist) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	return jsonValue(c)
+}
+
+func (c *Checklist) Scan(value any) error {
+	*c = Checklist{}
+	return scanJSON(value, c)
+}
+
+// CustomFieldDefinitions stores the custom fields of a project as a JSONB array.
+type CustomFieldDefinitions []domain.CustomFieldDefinition
+
+func (d CustomFieldDefinitions) Value() (driver.Value, error) {
+	if d == nil {
+		return "[]", nil
+	}
+	return jsonValue(d)
+}
+
+func (d *CustomFieldDefinitions) Scan(value any) error {
+	*d = CustomFieldDefinitions{}
+	return scanJSON(value, d)
+}
+
+// CustomFieldValues stores the custom field values of a todo as a JSONB object.
+type CustomFieldValues map[string]any
+
+func (v CustomFieldValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	return jsonValue(v)
+}
+
+func (v *CustomFieldValues) Scan(value any) error {
+	*v = CustomFieldValues{}
+	return scanJSON(value, v)
+}
+
+func jsonValue(v any) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// scanJSON decodes a JSONB column into dest, leaving dest untouched for NULL.
+func scanJSON(value any, dest any) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", value, dest)
+	}
+}
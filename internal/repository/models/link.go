@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TodoLink is one direction of a link between two todos; every link is
+// stored together with its inverse. A todo can be the duplicate of only one
+// other todo.
+type TodoLink struct {
+	TodoID       uint      `gorm:"primaryKey;uniqueIndex:idx_todo_links_duplicate_of,where:type = 'duplicate_of'"`
+	LinkedTodoID uint      `gorm:"primaryKey;index"`
+	Type         string    `gorm:"size:20;not null"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
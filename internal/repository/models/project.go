@@ -0,0 +1,41 @@
+package models
+
+import (
+	"go-todo-api/internal/domain"
+	"time"
+)
+
+type Project struct {
+	ID           uint   `gorm:"primaryKey"`
+	Name         string `gorm:"size:100;not null"`
+	Description  string
+	CustomFields CustomFieldDefinitions `gorm:"type:jsonb;not null;default:'[]'"`
+	CreatedAt    time.Time              `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time              `gorm:"autoUpdateTime"`
+}
+
+func (p *Project) ToDomain() *domain.Project {
+	project := &domain.Project{
+		ID:           p.ID,
+		Name:         p.Name,
+		Description:  p.Description,
+		CustomFields: p.CustomFields,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+	if project.CustomFields == nil {
+		project.CustomFields = []domain.CustomFieldDefinition{}
+	}
+	return project
+}
+
+func ProjectFromDomain(p *domain.Project) *Project {
+	return &Project{
+		ID:           p.ID,
+		Name:         p.Name,
+		Description:  p.Description,
+		CustomFields: p.CustomFields,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"go-todo-api/internal/domain"
+	"time"
+)
+
+type Reminder struct {
+	ID            uint       `gorm:"primaryKey"`
+	TodoID        uint       `gorm:"not null;index"`
+	RemindAt      *time.Time `gorm:"type:timestamptz"`
+	OffsetMinutes *int
+	FireAt        *time.Time `gorm:"type:timestamptz;index"`
+	Status        string     `gorm:"size:16;not null;default:pending;index"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time `gorm:"type:timestamptz"`
+	LastError     string
+	FiredAt       *time.Time `gorm:"type:timestamptz"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+}
+
+func (r *Reminder) ToDomain() *domain.Reminder {
+	return &domain.Reminder{
+		ID:            r.ID,
+		TodoID:        r.TodoID,
+		RemindAt:      r.RemindAt,
+		OffsetMinutes: r.OffsetMinutes,
+		FireAt:        r.FireAt,
+		Status:        domain.ReminderStatus(r.Status),
+		Attempts:      r.Attempts,
+		NextAttemptAt: r.NextAttemptAt,
+		LastError:     r.LastError,
+		FiredAt:       r.FiredAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+func ReminderFromDomain(r *domain.Reminder) *Reminder {
+	return &Reminder{
+		ID:            r.ID,
+		TodoID:        r.TodoID,
+		RemindAt:      r.RemindAt,
+		OffsetMinutes: r.OffsetMinutes,
+		FireAt:        r.FireAt,
+		Status:        string(r.Status),
+		Attempts:      r.Attempts,
+		NextAttemptAt: r.NextAttemptAt,
+		LastError:     r.LastError,
+		FiredAt:       r.FiredAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
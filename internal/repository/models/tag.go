@@ -0,0 +1,31 @@
+package models
+
+import (
+	"go-todo-api/internal/domain"
+	"time"
+)
+
+type Tag struct {
+	ID        uint      `gorm:"primaryKey"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (t *Tag) ToDomain() *domain.Tag {
+	return &domain.Tag{
+		ID:        t.ID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func TagFromDomain(t *domain.Tag) *Tag {
+	return &Tag{
+		ID:        t.ID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"go-todo-api/internal/domain"
+	"time"
+)
+
+type TimeEntry struct {
+	ID        uint       `gorm:"primaryKey"`
+	TodoID    uint       `gorm:"not null;index"`
+	User      string     `gorm:"column:user_name;size:100;not null;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL"`
+	StartedAt time.Time  `gorm:"type:timestamptz;not null;index"`
+	EndedAt   *time.Time `gorm:"type:timestamptz"`
+	Manual    bool       `gorm:"not null;default:false"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
+
+func (e *TimeEntry) ToDomain() *domain.TimeEntry {
+	return &domain.TimeEntry{
+		ID:        e.ID,
+		TodoID:    e.TodoID,
+		User:      e.User,
+		StartedAt: e.StartedAt,
+		EndedAt:   e.EndedAt,
+		Running:   e.EndedAt == nil,
+		Manual:    e.Manual,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func TimeEntryFromDomain(e *domain.TimeEntry) *TimeEntry {
+	return &TimeEntry{
+		ID:        e.ID,
+		TodoID:    e.TodoID,
+		User:      e.User,
+		StartedAt: e.StartedAt,
+		EndedAt:   e.EndedAt,
+		Manual:    e.Manual,
+		CreatedAt: e.CreatedAt,
+	}
+}
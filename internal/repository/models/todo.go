@@ -3,35 +3,137 @@ package models
 import (
 	"go-todo-api/internal/domain"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// Todo is the stored form of a todo. Completed mirrors whether Status is a
+// closed state of the workflow, so that queries can tell open from closed
+// todos without knowing the workflow.
 type Todo struct {
-	ID          uint      `gorm:"primaryKey"`
-	Title       string    `gorm:"not null"`
-	Description string
-	Completed   bool      `gorm:"default:false"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	ID                    uint   `gorm:"primaryKey"`
+	Title                 string `gorm:"not null"`
+	Description           string
+	Status                string     `gorm:"size:50;not null;default:'open';index"`
+	Completed             bool       `gorm:"default:false"`
+	CompletedAt           *time.Time `gorm:"type:timestamptz;index"`
+	Priority              int        `gorm:"type:smallint;not null;default:0;index"`
+	DueAt                 *time.Time `gorm:"type:timestamptz;index"`
+	Tags                  []Tag      `gorm:"many2many:todo_tags;constraint:OnDelete:CASCADE"`
+	ProjectID             *uint      `gorm:"index"`
+	ParentID              *uint      `gorm:"index"`
+	Recurrence            string     `gorm:"size:255"`
+	RecurrenceTimezone    string     `gorm:"size:64;not null;default:''"`
+	Occurrence            int        `gorm:"not null;default:0"`
+	NextOccurrenceID      *uint
+	Checklist             Checklist `gorm:"type:jsonb;not null;default:'[]'"`
+	ChecklistAutoComplete bool      `gorm:"not null;default:false"`
+	Position              string    `gorm:"size:255;not null;default:''"`
+	EstimateMinutes       *int
+	CustomFields          CustomFieldValues `gorm:"type:jsonb;not null;default:'{}'"`
+	ArchivedAt            *time.Time        `gorm:"type:timestamptz;index"`
+	CreatedAt             time.Time         `gorm:"autoCreateTime"`
+	UpdatedAt             time.Time         `gorm:"autoUpdateTime"`
+	DeletedAt             gorm.DeletedAt    `gorm:"type:timestamptz;index"`
+}
+
+// SortColumn is a column of todos that lists can be ordered by, with its SQL
+// type and whether it can be NULL.
+type SortColumn struct {
+	Column   string
+	Type     string
+	Nullable bool
+}
+
+// TodoSortColumns maps the fields todos can be sorted by to their columns.
+// Only these columns are ever put into an ORDER BY, so a sort parameter
+// cannot inject SQL.
+var TodoSortColumns = map[string]SortColumn{
+	"id":               {Column: "id", Type: "integer"},
+	"title":            {Column: "title", Type: "text"},
+	"status":           {Column: "status", Type: "text"},
+	"priority":         {Column: "priority", Type: "integer"},
+	"due_at":           {Column: "due_at", Type: "timestamptz", Nullable: true},
+	"completed_at":     {Column: "completed_at", Type: "timestamptz", Nullable: true},
+	"archived_at":      {Column: "archived_at", Type: "timestamptz", Nullable: true},
+	"estimate_minutes": {Column: "estimate_minutes", Type: "integer", Nullable: true},
+	"position":         {Column: `position COLLATE "C"`, Type: "text"},
+	"created_at":       {Column: "created_at", Type: "timestamptz", Nullable: true},
+	"updated_at":       {Column: "updated_at", Type: "timestamptz", Nullable: true},
 }
 
 func (t *Todo) ToDomain() *domain.Todo {
-	return &domain.Todo{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		Completed:   t.Completed,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+	todo := &domain.Todo{
+		ID:                    t.ID,
+		Title:                 t.Title,
+		Description:           t.Description,
+		Status:                domain.Status(t.Status),
+		Completed:             t.Completed,
+		CompletedAt:           t.CompletedAt,
+		Priority:              domain.PriorityFromRank(t.Priority),
+		DueAt:                 t.DueAt,
+		Tags:                  make([]domain.Tag, len(t.Tags)),
+		ProjectID:             t.ProjectID,
+		ParentID:              t.ParentID,
+		Recurrence:            t.Recurrence,
+		RecurrenceTimezone:    t.RecurrenceTimezone,
+		Occurrence:            t.Occurrence,
+		NextOccurrenceID:      t.NextOccurrenceID,
+		Checklist:             t.Checklist,
+		ChecklistAutoComplete: t.ChecklistAutoComplete,
+		Position:              t.Position,
+		EstimateMinutes:       t.EstimateMinutes,
+		CustomFields:          t.CustomFields,
+		Archived:              t.ArchivedAt != nil,
+		ArchivedAt:            t.ArchivedAt,
+		CreatedAt:             t.CreatedAt,
+		UpdatedAt:             t.UpdatedAt,
+	}
+	if t.DeletedAt.Valid {
+		deletedAt := t.DeletedAt.Time
+		todo.DeletedAt = &deletedAt
 	}
+	if todo.Checklist == nil {
+		todo.Checklist = []domain.ChecklistItem{}
+	}
+	if todo.CustomFields == nil {
+		todo.CustomFields = map[string]any{}
+	}
+	for i := range t.Tags {
+		todo.Tags[i] = *t.Tags[i].ToDomain()
+	}
+	return todo
 }
 
 func FromDomain(t *domain.Todo) *Todo {
-	return &Todo{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		Completed:   t.Completed,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+	priority, _ := t.Priority.Rank()
+	todo := &Todo{
+		ID:                    t.ID,
+		Title:                 t.Title,
+		Description:           t.Description,
+		Status:                string(t.Status),
+		Completed:             t.Completed,
+		CompletedAt:           t.CompletedAt,
+		Priority:              priority,
+		DueAt:                 t.DueAt,
+		Tags:                  make([]Tag, len(t.Tags)),
+		ProjectID:             t.ProjectID,
+		ParentID:              t.ParentID,
+		Recurrence:            t.Recurrence,
+		RecurrenceTimezone:    t.RecurrenceTimezone,
+		Occurrence:            t.Occurrence,
+		NextOccurrenceID:      t.NextOccurrenceID,
+		Checklist:             t.Checklist,
+		ChecklistAutoComplete: t.ChecklistAutoComplete,
+		Position:              t.Position,
+		EstimateMinutes:       t.EstimateMinutes,
+		CustomFields:          t.CustomFields,
+		ArchivedAt:            t.ArchivedAt,
+		CreatedAt:             t.CreatedAt,
+		UpdatedAt:             t.UpdatedAt,
 	}
-} 
+	for i := range t.Tags {
+		todo.Tags[i] = *TagFromDomain(&t.Tags[i])
+	}
+	return todo
+}
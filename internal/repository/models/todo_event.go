@@ -0,0 +1,24 @@
+package models
+
+import (
+	"go-todo-api/internal/domain"
+	"time"
+)
+
+type TodoEvent struct {
+	ID        uint      `gorm:"primaryKey"`
+	TodoID    uint      `gorm:"not null;index"`
+	Type      string    `gorm:"size:20;not null"`
+	Status    string    `gorm:"size:50;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (e *TodoEvent) ToDomain() *domain.TodoEvent {
+	return &domain.TodoEvent{
+		ID:        e.ID,
+		TodoID:    e.TodoID,
+		Type:      domain.TodoEventType(e.Type),
+		Status:    domain.Status(e.Status),
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"strings"
+
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type projectRepository struct {
+	db    *gorm.DB
+	blobs domain.BlobStore
+}
+
+// NewProjectRepository creates the project repository. blobs is needed to
+// remove the attachments of todos deleted along with a project.
+func NewProjectRepository(db *gorm.DB, blobs domain.BlobStore) domain.ProjectRepository {
+	db.AutoMigrate(&models.Project{})
+	return &projectRepository{
+		db:    db,
+		blobs: blobs,
+	}
+}
+
+func (r *projectRepository) Create(project *domain.Project) error {
+	dbProject := models.ProjectFromDomain(project)
+	if err := r.db.Create(dbProject).Error; err != nil {
+		return err
+	}
+	*project = *dbProject.ToDomain()
+	return nil
+}
+
+func (r *projectRepository) GetByID(id uint) (*domain.Project, error) {
+	var dbProject models.Project
+	err := r.db.First(&dbProject, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbProject.ToDomain(), nil
+}
+
+func (r *projectRepository) GetAll() ([]*domain.Project, error) {
+	var dbProjects []models.Project
+	if err := r.db.Order("name").Find(&dbProjects).Error; err != nil {
+		return nil, err
+	}
+
+	projects := make([]*domain.Project, len(dbProjects))
+	for i, dbProject := range dbProjects {
+		projects[i] = dbProject.ToDomain()
+	}
+	return projects, nil
+}
+
+func (r *projectRepository) Update(project *domain.Project) error {
+	dbProject := models.ProjectFromDomain(project)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(dbProject).Select("name", "description", "custom_fields").Updates(dbProject)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		if err := pruneCustomFieldValues(tx, dbProject); err != nil {
+			return err
+		}
+		return tx.First(dbProject, project.ID).Error
+	})
+	if err != nil {
+		return err
+	}
+	*project = *dbProject.ToDomain()
+	return nil
+}
+
+// pruneCustomFieldValues drops the values of custom fields that a project no
+// longer defines from its todos, as well as values of enum fields that are
+// no longer among the field's options.
+func pruneCustomFieldValues(tx *gorm.DB, dbProject *models.Project) error {
+	keys := make([]string, len(dbProject.CustomFields))
+	for i, field := range dbProject.CustomFields {
+		keys[i] = field.Key
+	}
+	keep := []string{"key IN ?"}
+	vars := []any{keys}
+	for _, field := range dbProject.CustomFields {
+		if field.Type == domain.CustomFieldEnum {
+			keep = append(keep, "(key <> ? OR value #>> '{}' IN ?)")
+			vars = append(vars, field.Key, field.Options)
+		}
+	}
+	vars = append(vars, dbProject.ID)
+	return tx.Exec(`
+UPDATE todos
+SET custom_fields = (
+	SELECT COALESCE(jsonb_object_agg(key, value), '{}') FROM jsonb_each(todos.custom_fields) WHERE `+strings.Join(keep, " AND ")+`
+)
+WHERE project_id = ? AND custom_fields <> '{}'`, vars...).Error
+}
+
+func (r *projectRepository) Delete(id uint, cascade bool) error {
+	var blobKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Todos of the project in the trash are removed with it, whether
+		// or not the delete cascades, as they could not be restored.
+		var todoIDs []uint
+		err := tx.Unscoped().Model(&models.Todo{}).Where("project_id = ?", id).Pluck("id", &todoIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(todoIDs) > 0 {
+			if !cascade {
+				var todos int64
+				if err := tx.Model(&models.Todo{}).Where("project_id = ?", id).Count(&todos).Error; err != nil {
+					return err
+				}
+				if todos > 0 {
+					return domain.ErrProjectNotEmpty
+				}
+			}
+			if blobKeys, err = deleteTodos(tx, todoIDs); err != nil {
+				return err
+			}
+		}
+
+		result := tx.Delete(&models.Project{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	removeBlobs(r.blobs, blobKeys)
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) domain.ReminderRepository {
+	db.AutoMigrate(&models.Reminder{})
+	return &reminderRepository{
+		db: db,
+	}
+}
+
+func (r *reminderRepository) Create(reminder *domain.Reminder) error {
+	dbReminder := models.ReminderFromDomain(reminder)
+	if err := r.db.Create(dbReminder).Error; err != nil {
+		return err
+	}
+	*reminder = *dbReminder.ToDomain()
+	return nil
+}
+
+func (r *reminderRepository) GetByID(id uint) (*domain.Reminder, error) {
+	var dbReminder models.Reminder
+	err := r.db.First(&dbReminder, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbReminder.ToDomain(), nil
+}
+
+func (r *reminderRepository) GetByTodoID(todoID uint) ([]*domain.Reminder, error) {
+	var dbReminders []models.Reminder
+	err := r.db.Where("todo_id = ?", todoID).Order("fire_at ASC NULLS LAST").Order("id").Find(&dbReminders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]*domain.Reminder, len(dbReminders))
+	for i, dbReminder := range dbReminders {
+		reminders[i] = dbReminder.ToDomain()
+	}
+	return reminders, nil
+}
+
+func (r *reminderRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Reminder{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ProcessDue keeps the row locked with FOR UPDATE SKIP LOCKED while handle
+// runs, so other instances skip it, and commits the outcome together with
+// the release of the lock. A crash before the commit leaves the reminder
+// pending, to be picked up again after a restart.
+func (r *reminderRepository) ProcessDue(now time.Time, handle func(reminder *domain.Reminder) error) (bool, error) {
+	found := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var dbReminder models.Reminder
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND fire_at <= ?", domain.ReminderPending, now).
+			Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+			Where("todo_id NOT IN (SELECT id FROM todos WHERE deleted_at IS NOT NULL)").
+			Order("fire_at").
+			Take(&dbReminder).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		found = true
+
+		reminder := dbReminder.ToDomain()
+		if err := handle(reminder); err != nil {
+			return err
+		}
+
+		dbReminder = *models.ReminderFromDomain(reminder)
+		return tx.Model(&dbReminder).
+			Select("status", "attempts", "next_attempt_at", "last_error", "fired_at").
+			Updates(&dbReminder).Error
+	})
+	return found, err
+}
+
+// rescheduleReminders moves the offset reminders of a todo that have not
+// fired yet along with its due date.
+func rescheduleReminders(tx *gorm.DB, todoID uint) error {
+	return tx.Exec(`
+UPDATE reminders
+SET fire_at = (SELECT due_at FROM todos WHERE todos.id = reminders.todo_id) - offset_minutes * INTERVAL '1 minute',
+	next_attempt_at = NULL
+WHERE todo_id = ? AND offset_minutes IS NOT NULL AND status = ?`, todoID, domain.ReminderPending).Error
+}
+
+// copyOffsetReminders gives the next occurrence of a recurring todo the same
+// offset reminders as the occurrence it follows.
+func copyOffsetReminders(tx *gorm.DB, fromTodoID, toTodoID uint) error {
+	return tx.Exec(`
+INSERT INTO reminders (todo_id, offset_minutes, fire_at, status, attempts, created_at)
+SELECT ?, r.offset_minutes, t.due_at - r.offset_minutes * INTERVAL '1 minute', ?, 0, NOW()
+FROM reminders r, todos t
+WHERE r.todo_id = ? AND r.offset_minutes IS NOT NULL AND t.id = ?`,
+		toTodoID, domain.ReminderPending, fromTodoID, toTodoID).Error
+}
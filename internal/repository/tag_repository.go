@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"errors"
+
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+	"gorm.io/gorm"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) domain.TagRepository {
+	db.AutoMigrate(&models.Tag{})
+	return &tagRepository{
+		db: db,
+	}
+}
+
+func (r *tagRepository) Create(tag *domain.Tag) error {
+	dbTag := models.TagFromDomain(tag)
+	if err := r.db.Create(dbTag).Error; err != nil {
+		return translateTagError(err)
+	}
+	*tag = *dbTag.ToDomain()
+	return nil
+}
+
+func (r *tagRepository) GetByID(id uint) (*domain.Tag, error) {
+	var dbTag models.Tag
+	err := r.db.First(&dbTag, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbTag.ToDomain(), nil
+}
+
+func (r *tagRepository) GetByIDs(ids []uint) ([]*domain.Tag, error) {
+	var dbTags []models.Tag
+	if err := r.db.Where("id IN ?", ids).Order("name").Find(&dbTags).Error; err != nil {
+		return nil, err
+	}
+	return tagsToDomain(dbTags), nil
+}
+
+func (r *tagRepository) GetAll() ([]*domain.Tag, error) {
+	var dbTags []models.Tag
+	if err := r.db.Order("name").Find(&dbTags).Error; err != nil {
+		return nil, err
+	}
+	return tagsToDomain(dbTags), nil
+}
+
+// Update renames a tag. Todos reference tags by ID, so they pick up the new
+// name automatically; their updated_at is bumped so clients notice the change.
+func (r *tagRepository) Update(tag *domain.Tag) error {
+	dbTag := models.TagFromDomain(tag)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(dbTag).Select("name").Updates(dbTag)
+		if result.Error != nil {
+			return translateTagError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		if err := touchTaggedTodos(tx, tag.ID); err != nil {
+			return err
+		}
+		return tx.First(dbTag, tag.ID).Error
+	})
+	if err != nil {
+		return err
+	}
+	*tag = *dbTag.ToDomain()
+	return nil
+}
+
+// Delete removes a tag together with its assignments to todos.
+func (r *tagRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := touchTaggedTodos(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM todo_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Tag{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func touchTaggedTodos(tx *gorm.DB, tagID uint) error {
+	return tx.Exec(
+		"UPDATE todos SET updated_at = NOW() WHERE id IN (SELECT todo_id FROM todo_tags WHERE tag_id = ?)",
+		tagID,
+	).Error
+}
+
+func translateTagError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrTagExists
+	}
+	return err
+}
+
+func tagsToDomain(dbTags []models.Tag) []*domain.Tag {
+	tags := make([]*domain.Tag, len(dbTags))
+	for i, dbTag := range dbTags {
+		tags[i] = dbTag.ToDomain()
+	}
+	return tags
+}
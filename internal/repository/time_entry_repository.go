@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"errors"
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type timeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) domain.TimeEntryRepository {
+	db.AutoMigrate(&models.TimeEntry{})
+	return &timeEntryRepository{
+		db: db,
+	}
+}
+
+// Create stores a time entry. A second running entry for the same user
+// violates the partial unique index on running entries.
+func (r *timeEntryRepository) Create(entry *domain.TimeEntry) error {
+	dbEntry := models.TimeEntryFromDomain(entry)
+	err := r.db.Create(dbEntry).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.ErrTimerRunning
+	}
+	if err != nil {
+		return err
+	}
+	*entry = *dbEntry.ToDomain()
+	return nil
+}
+
+func (r *timeEntryRepository) GetByID(id uint) (*domain.TimeEntry, error) {
+	var dbEntry models.TimeEntry
+	err := r.db.First(&dbEntry, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dbEntry.ToDomain(), nil
+}
+
+func (r *timeEntryRepository) GetByTodoID(todoID uint) ([]*domain.TimeEntry, error) {
+	var dbEntries []models.TimeEntry
+	err := r.db.Where("todo_id = ?", todoID).Order("started_at").Order("id").Find(&dbEntries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.TimeEntry, len(dbEntries))
+	for i, dbEntry := range dbEntries {
+		entries[i] = dbEntry.ToDomain()
+	}
+	return entries, nil
+}
+
+func (r *timeEntryRepository) Stop(todoID uint, user string, endedAt time.Time) (*domain.TimeEntry, error) {
+	var dbEntries []models.TimeEntry
+	err := r.db.Model(&dbEntries).
+		Clauses(clause.Returning{}).
+		Where("todo_id = ? AND user_name = ? AND ended_at IS NULL", todoID, user).
+		Update("ended_at", endedAt).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(dbEntries) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return dbEntries[0].ToDomain(), nil
+}
+
+func (r *timeEntryRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.TimeEntry{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Report sums the time of all entries overlapping the filter's range,
+// counting only the part inside the range and running entries up to now.
+// Todos in the trash are left out.
+func (r *timeEntryRepository) Report(filter domain.TimeReportFilter) (*domain.TimeReport, error) {
+	query := r.db.Table("time_entries e").
+		Select(`t.id AS todo_id, t.title, t.project_id, t.estimate_minutes,
+SUM(EXTRACT(EPOCH FROM LEAST(COALESCE(e.ended_at, NOW()), ?) - GREATEST(e.started_at, ?))) AS seconds`,
+			filter.To, filter.From).
+		Joins("JOIN todos t ON t.id = e.todo_id AND t.deleted_at IS NULL").
+		Where("e.started_at < ? AND COALESCE(e.ended_at, NOW()) > ?", filter.To, filter.From)
+	if filter.ProjectID != nil {
+		query = query.Where("t.project_id = ?", *filter.ProjectID)
+	}
+	if filter.User != "" {
+		query = query.Where("e.user_name = ?", filter.User)
+	}
+
+	var rows []struct {
+		TodoID          uint
+		Title           string
+		ProjectID       *uint
+		EstimateMinutes *int
+		Seconds         float64
+	}
+	err := query.Group("t.id").Order("seconds DESC").Order("t.id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.TimeReport{
+		From:     filter.From,
+		To:       filter.To,
+		Todos:    make([]domain.TodoTime, len(rows)),
+		Projects: []domain.ProjectTime{},
+	}
+	var total float64
+	projectSeconds := make(map[uint]float64)
+	var noProjectSeconds float64
+	var projectIDs []uint
+	for i, row := range rows {
+		report.Todos[i] = domain.TodoTime{
+			TodoID:          row.TodoID,
+			Title:           row.Title,
+			ProjectID:       row.ProjectID,
+			EstimateMinutes: row.EstimateMinutes,
+			Minutes:         int(row.Seconds / 60),
+		}
+		total += row.Seconds
+		if row.ProjectID == nil {
+			noProjectSeconds += row.Seconds
+			continue
+		}
+		if _, ok := projectSeconds[*row.ProjectID]; !ok {
+			projectIDs = append(projectIDs, *row.ProjectID)
+		}
+		projectSeconds[*row.ProjectID] += row.Seconds
+	}
+	report.TotalMinutes = int(total / 60)
+
+	var projects []models.Project
+	if len(projectIDs) > 0 {
+		if err := r.db.Where("id IN ?", projectIDs).Order("name").Order("id").Find(&projects).Error; err != nil {
+			return nil, err
+		}
+	}
+	for _, project := range projects {
+		report.Projects = append(report.Projects, domain.ProjectTime{
+			ProjectID: &project.ID,
+			Name:      project.Name,
+			Minutes:   int(projectSeconds[project.ID] / 60),
+		})
+	}
+	if noProjectSeconds > 0 {
+		report.Projects = append(report.Projects, domain.ProjectTime{
+			Minutes: int(noProjectSeconds / 60),
+		})
+	}
+	return report, nil
+}
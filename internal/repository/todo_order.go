@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sortKey is one expression todos are ordered by. Nullable keys sort their
+// NULLs last in either direction. Every order ends with id, which makes it
+// total and lets keyset pagination continue right after the last todo of a
+// page.
+type sortKey struct {
+	sql      string
+	vars     []any
+	sqlType  string
+	desc     bool
+	nullable bool
+}
+
+// param is a placeholder for a value of the key in its text form. The value
+// is passed as text and cast in SQL, so the driver does not have to convert
+// it.
+func (k sortKey) param() string {
+	if k.sqlType == "text" {
+		return "?::text"
+	}
+	return "?::text::" + k.sqlType
+}
+
+// todoOrder returns the keys todos are ordered by for filter and a name for
+// the order, which cursors carry so they are not used with another order.
+// Fields are looked up in models.TodoSortColumns; ties are broken by the
+// manual order and finally by id, unless id is sorted by already.
+func todoOrder(filter domain.TodoFilter) (string, []sortKey, error) {
+	var names []string
+	var keys []sortKey
+	seen := make(map[string]bool)
+	for _, k := range filter.Sort {
+		var key sortKey
+		name := k.Field
+		if k.CustomField != "" {
+			key = customFieldKey(k.CustomField, k.CustomFieldType)
+			name = "cf." + k.CustomField + ":" + string(k.CustomFieldType)
+		} else {
+			column, ok := models.TodoSortColumns[k.Field]
+			if !ok {
+				return "", nil, fmt.Errorf("%w: cannot sort by %q", domain.ErrInvalidFilter, k.Field)
+			}
+			key = sortKey{sql: column.Column, sqlType: column.Type, nullable: column.Nullable}
+		}
+		key.desc = k.Desc
+		if k.Desc {
+			name = "-" + name
+		}
+		names = append(names, name)
+		keys = append(keys, key)
+		seen[key.sql] = true
+	}
+
+	for _, column := range []string{"position", "id"} {
+		if seen["id"] {
+			break
+		}
+		if c := models.TodoSortColumns[column]; !seen[c.Column] {
+			keys = append(keys, sortKey{sql: c.Column, sqlType: c.Type})
+			names = append(names, column)
+		}
+	}
+	return strings.Join(names, ","), keys, nil
+}
+
+// customFieldKey orders todos by the value of a custom field, comparing
+// numbers and booleans by value, and puts todos without a value last. Values
+// of another JSON type, left behind by older definitions, count as missing.
+func customFieldKey(key string, fieldType domain.CustomFieldType) sortKey {
+	switch fieldType {
+	case domain.CustomFieldNumber:
+		return sortKey{
+			sql:      "CASE WHEN jsonb_typeof(custom_fields -> ?) = 'number' THEN (custom_fields ->> ?)::numeric END",
+			vars:     []any{key, key},
+			sqlType:  "numeric",
+			nullable: true,
+		}
+	case domain.CustomFieldBoolean:
+		return sortKey{
+			sql:      "CASE WHEN jsonb_typeof(custom_fields -> ?) = 'boolean' THEN (custom_fields ->> ?)::boolean END",
+			vars:     []any{key, key},
+			sqlType:  "boolean",
+			nullable: true,
+		}
+	}
+	return sortKey{sql: "custom_fields ->> ?", vars: []any{key}, sqlType: "text", nullable: true}
+}
+
+// orderBy builds the ORDER BY clause for keys. It is a single expression
+// because GORM drops the expression of an ORDER BY clause when further
+// columns are added.
+func orderBy(keys []sortKey) clause.OrderBy {
+	var sql []string
+	var vars []any
+	for _, key := range keys {
+		column := key.sql
+		if key.desc {
+			column += " DESC"
+		}
+		if key.nullable {
+			column += " NULLS LAST"
+		}
+		sql = append(sql, column)
+		vars = append(vars, key.vars...)
+	}
+	return clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(sql, ", "), Vars: vars}}
+}
+
+// selectSortKeys selects the id and the sort keys of todos as text, which is
+// what a cursor stores.
+func selectSortKeys(db *gorm.DB, keys []sortKey) *gorm.DB {
+	sql := []string{"id"}
+	var vars []any
+	for _, key := range keys {
+		sql = append(sql, "("+key.sql+")::text")
+		vars = append(vars, key.vars...)
+	}
+	return db.Select(strings.Join(sql, ", "), vars...)
+}
+
+// afterKeys matches the todos that come after the todo whose sort keys have
+// the given values. A nil value is a NULL.
+func afterKeys(keys []sortKey, values []*string) clause.Expr {
+	// Without NULLs and with a single direction a row comparison does, and
+	// unlike the general form it can use an index.
+	simple := true
+	for _, key := range keys {
+		simple = simple && !key.nullable && key.desc == keys[0].desc
+	}
+	if simple {
+		var columns, params []string
+		var vars []any
+		for _, key := range keys {
+			columns = append(columns, key.sql)
+			vars = append(vars, key.vars...)
+		}
+		for i, key := range keys {
+			params = append(params, key.param())
+			vars = append(vars, *values[i])
+		}
+		op := " > "
+		if keys[0].desc {
+			op = " < "
+		}
+		return clause.Expr{SQL: "(" + strings.Join(columns, ", ") + ")" + op + "(" + strings.Join(params, ", ") + ")", Vars: vars}
+	}
+
+	key, value := keys[0], values[0]
+	var rest *clause.Expr
+	if len(keys) > 1 {
+		expr := afterKeys(keys[1:], values[1:])
+		rest = &expr
+	}
+
+	// NULLs come last, so nothing but NULLs follows a NULL.
+	if value == nil {
+		if rest == nil {
+			return clause.Expr{SQL: "FALSE"}
+		}
+		return clause.Expr{
+			SQL:  "((" + key.sql + ") IS NULL AND ?)",
+			Vars: append(append([]any{}, key.vars...), *rest),
+		}
+	}
+
+	op := " > "
+	if key.desc {
+		op = " < "
+	}
+	sql := "(" + key.sql + ")" + op + key.param()
+	vars := append(append([]any{}, key.vars...), *value)
+	if key.nullable {
+		sql += " OR (" + key.sql + ") IS NULL"
+		vars = append(vars, key.vars...)
+	}
+	if rest != nil {
+		sql += " OR ((" + key.sql + ") = " + key.param() + " AND ?)"
+		vars = append(vars, key.vars...)
+		vars = append(vars, *value, *rest)
+	}
+	return clause.Expr{SQL: "(" + sql + ")", Vars: vars}
+}
+
+// todoCursor is the position after which the next page of todos starts.
+type todoCursor struct {
+	Order  string    `json:"o"`
+	Values []*string `json:"v"`
+}
+
+func encodeCursor(order string, values []*string) string {
+	data, _ := json.Marshal(todoCursor{Order: order, Values: values})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns the sort key values stored in a cursor created for
+// the order with the given name and keys. The values are checked so that a
+// tampered cursor is rejected rather than failing in the database.
+func decodeCursor(cursor, order string, keys []sortKey) ([]*string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	var c todoCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Order != order || len(c.Values) != len(keys) {
+		return nil, domain.ErrInvalidCursor
+	}
+	for i, key := range keys {
+		value := c.Values[i]
+		if value == nil && !key.nullable || value != nil && !validSortValue(key.sqlType, *value) {
+			return nil, domain.ErrInvalidCursor
+		}
+	}
+	return c.Values, nil
+}
+
+// postgresTimestampLayouts are the text forms of timestamptz values in the
+// ISO date style, with an offset in hours or in hours and minutes.
+var postgresTimestampLayouts = []string{
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05.999999-07:00",
+}
+
+// validSortValue reports whether v is the text form of a value of sqlType.
+func validSortValue(sqlType, v string) bool {
+	switch sqlType {
+	case "integer":
+		_, err := strconv.ParseInt(v, 10, 32)
+		return err == nil
+	case "numeric":
+		_, ok := new(big.Float).SetString(v)
+		return ok
+	case "boolean":
+		return v == "true" || v == "false"
+	case "timestamptz":
+		for _, layout := range postgresTimestampLayouts {
+			if _, err := time.Parse(layout, v); err == nil {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
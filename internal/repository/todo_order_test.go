@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-todo-api/internal/domain"
+
+	"gorm.io/gorm/clause"
+)
+
+// renderExpr inlines the variables of expr, quoting strings, so that the SQL
+// can be compared as a whole.
+func renderExpr(expr clause.Expr) string {
+	var b strings.Builder
+	vars := expr.Vars
+	for _, r := range expr.SQL {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		switch v := vars[0].(type) {
+		case clause.Expr:
+			b.WriteString(renderExpr(v))
+		case string:
+			b.WriteString("'" + strings.ReplaceAll(v, "'", "''") + "'")
+		default:
+			fmt.Fprint(&b, v)
+		}
+		vars = vars[1:]
+	}
+	return b.String()
+}
+
+func strs(values ...string) []*string {
+	result := make([]*string, len(values))
+	for i, v := range values {
+		if v != "NULL" {
+			result[i] = &values[i]
+		}
+	}
+	return result
+}
+
+func TestAfterKeys(t *testing.T) {
+	const (
+		rest = `(position COLLATE "C", id) > ('V'::text, '7'::text::integer)`
+		due  = `'2026-10-18 12:00:00+02'::text::timestamptz`
+		cf   = `CASE WHEN jsonb_typeof(custom_fields -> 'points') = 'number' THEN (custom_fields ->> 'points')::numeric END`
+		team = `custom_fields ->> 'team'`
+	)
+	tests := []struct {
+		name   string
+		sort   []domain.SortKey
+		values []*string
+		want   string
+	}{
+		{
+			name:   "manual order",
+			values: strs("V", "7"),
+			want:   rest,
+		},
+		{
+			name:   "descending",
+			sort:   []domain.SortKey{{Field: "priority", Desc: true}, {Field: "id", Desc: true}},
+			values: strs("3", "7"),
+			want:   `(priority, id) < ('3'::text::integer, '7'::text::integer)`,
+		},
+		{
+			name:   "mixed directions",
+			sort:   []domain.SortKey{{Field: "priority", Desc: true}},
+			values: strs("3", "V", "7"),
+			want:   `((priority) < '3'::text::integer OR ((priority) = '3'::text::integer AND ` + rest + `))`,
+		},
+		{
+			name:   "nullable",
+			sort:   []domain.SortKey{{Field: "due_at"}},
+			values: strs("2026-10-18 12:00:00+02", "V", "7"),
+			want:   `((due_at) > ` + due + ` OR (due_at) IS NULL OR ((due_at) = ` + due + ` AND ` + rest + `))`,
+		},
+		{
+			name:   "nullable at NULL",
+			sort:   []domain.SortKey{{Field: "due_at"}},
+			values: strs("NULL", "V", "7"),
+			want:   `((due_at) IS NULL AND ` + rest + `)`,
+		},
+		{
+			name: "nullable and mixed directions",
+			sort: []domain.SortKey{
+				{Field: "priority", Desc: true},
+				{Field: "due_at", Desc: true},
+			},
+			values: strs("3", "2026-10-18 12:00:00+02", "V", "7"),
+			want: `((priority) < '3'::text::integer OR ((priority) = '3'::text::integer AND ` +
+				`((due_at) < ` + due + ` OR (due_at) IS NULL OR ((due_at) = ` + due + ` AND ` + rest + `))))`,
+		},
+		{
+			name:   "custom fields",
+			sort:   []domain.SortKey{{CustomField: "points", CustomFieldType: domain.CustomFieldNumber, Desc: true}, {CustomField: "team"}},
+			values: strs("1.5", "NULL", "V", "7"),
+			want: `((` + cf + `) < '1.5'::text::numeric OR (` + cf + `) IS NULL OR ((` + cf + `) = '1.5'::text::numeric AND ` +
+				`((` + team + `) IS NULL AND ` + rest + `)))`,
+		},
+		{
+			name:   "last key NULL",
+			sort:   []domain.SortKey{{Field: "due_at"}, {Field: "id"}},
+			values: strs("NULL", "7"),
+			want:   `((due_at) IS NULL AND (id) > ('7'::text::integer))`,
+		},
+		{
+			name:   "quotes in values",
+			sort:   []domain.SortKey{{Field: "title"}},
+			values: strs("it's", "V", "7"),
+			want:   `(title, position COLLATE "C", id) > ('it''s'::text, 'V'::text, '7'::text::integer)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, keys, err := todoOrder(domain.TodoFilter{Sort: tt.sort})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(keys) != len(tt.values) {
+				t.Fatalf("order has %d keys, the test gives %d values", len(keys), len(tt.values))
+			}
+			if got := renderExpr(afterKeys(keys, tt.values)); got != tt.want {
+				t.Errorf("afterKeys()\n got %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodoOrder(t *testing.T) {
+	tests := []struct {
+		sort []domain.SortKey
+		want string
+	}{
+		{nil, "position,id"},
+		{[]domain.SortKey{{Field: "priority", Desc: true}, {Field: "due_at"}}, "-priority,due_at,position,id"},
+		{[]domain.SortKey{{Field: "position"}}, "position,id"},
+		{[]domain.SortKey{{Field: "id", Desc: true}}, "-id"},
+		{[]domain.SortKey{{CustomField: "points", CustomFieldType: domain.CustomFieldNumber}}, "cf.points:number,position,id"},
+	}
+	for _, tt := range tests {
+		if got, _, err := todoOrder(domain.TodoFilter{Sort: tt.sort}); err != nil || got != tt.want {
+			t.Errorf("todoOrder(%+v) = %q, %v, want %q", tt.sort, got, err, tt.want)
+		}
+	}
+	if _, _, err := todoOrder(domain.TodoFilter{Sort: []domain.SortKey{{Field: "id; DROP TABLE todos"}}}); !errors.Is(err, domain.ErrInvalidFilter) {
+		t.Errorf("todoOrder() with an unknown field error = %v, want %v", err, domain.ErrInvalidFilter)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	order, keys, err := todoOrder(domain.TodoFilter{Sort: []domain.SortKey{{Field: "due_at"}, {Field: "priority", Desc: true}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, values := range [][]*string{
+		strs("2026-10-18 12:00:00.123456+02", "3", "V", "7"),
+		strs("NULL", "0", "0V", "12"),
+	} {
+		got, err := decodeCursor(encodeCursor(order, values), order, keys)
+		if err != nil {
+			t.Fatalf("decodeCursor() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, values) {
+			t.Errorf("decodeCursor() = %v, want %v", got, values)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "!!"},
+		{"not JSON", "bm90IGpzb24"},
+		{"another order", encodeCursor("position,id", strs("V", "7"))},
+		{"too few values", encodeCursor(order, strs("NULL", "3", "V"))},
+		{"too many values", encodeCursor(order, strs("NULL", "3", "V", "7", "8"))},
+		{"NULL for a key that is never NULL", encodeCursor(order, strs("NULL", "NULL", "V", "7"))},
+		{"bad integer", encodeCursor(order, strs("NULL", "3", "V", "7) OR (1=1"))},
+		{"bad timestamp", encodeCursor(order, strs("tomorrow", "3", "V", "7"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if values, err := decodeCursor(tt.cursor, order, keys); !errors.Is(err, domain.ErrInvalidCursor) {
+				t.Errorf("decodeCursor() = %v, %v, want %v", values, err, domain.ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestValidSortValue(t *testing.T) {
+	tests := []struct {
+		sqlType string
+		value   string
+		want    bool
+	}{
+		{"integer", "42", true},
+		{"integer", "-1", true},
+		{"integer", "1.5", false},
+		{"integer", "99999999999", false},
+		{"integer", "", false},
+		{"numeric", "1.5", true},
+		{"numeric", "-2e3", true},
+		{"numeric", "1.5'", false},
+		{"boolean", "true", true},
+		{"boolean", "false", true},
+		{"boolean", "t", false},
+		{"timestamptz", "2026-10-18 12:00:00+02", true},
+		{"timestamptz", "2026-10-18 12:00:00.123456+05:30", true},
+		{"timestamptz", "2026-10-18T12:00:00Z", false},
+		{"timestamptz", "2026-10-18", false},
+		{"text", "anything' goes", true},
+	}
+	for _, tt := range tests {
+		if got := validSortValue(tt.sqlType, tt.value); got != tt.want {
+			t.Errorf("validSortValue(%q, %q) = %v, want %v", tt.sqlType, tt.value, got, tt.want)
+		}
+	}
+}
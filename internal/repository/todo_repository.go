@@ -1,25 +1,85 @@
 package repository
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
 	"go-todo-api/internal/domain"
 	"go-todo-api/internal/repository/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// subtreeQuery selects the IDs of a todo and all of its descendants. UNION
+// rather than UNION ALL keeps the recursion finite even if a cycle slipped in.
+const subtreeQuery = `
+WITH RECURSIVE subtree AS (
+	SELECT id FROM todos WHERE id = ?
+	UNION
+	SELECT t.id FROM todos t JOIN subtree s ON t.parent_id = s.id
+)
+SELECT id FROM subtree`
+
+// blockersQuery selects the IDs of all todos that block a todo, directly or
+// through other todos.
+const blockersQuery = `
+WITH RECURSIVE blockers AS (
+	SELECT blocked_by_id AS id FROM todo_dependencies WHERE todo_id = ?
+	UNION
+	SELECT d.blocked_by_id FROM todo_dependencies d JOIN blockers b ON d.todo_id = b.id
 )
+SELECT id FROM blockers`
+
+// openBlockerQuery matches todos that are blocked by at least one open todo.
+const openBlockerQuery = `EXISTS (
+	SELECT 1 FROM todo_dependencies d JOIN todos b ON b.id = d.blocked_by_id
+	WHERE d.todo_id = todos.id AND NOT b.completed AND b.deleted_at IS NULL
+)`
+
+// positionColumn compares positions byte by byte, whatever the collation of
+// the database, which is the order positions are designed for.
+const positionColumn = `position COLLATE "C"`
+
+// rebalanceBatchSize is the number of todos updated per statement when
+// rebalancing positions.
+const rebalanceBatchSize = 1000
+
+// purgeBatchSize is the number of deleted todos removed per transaction when
+// purging the trash.
+const purgeBatchSize = 1000
+
+// maxTreeWalk bounds recursive queries over the todo hierarchy.
+const maxTreeWalk = 64
 
 type todoRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	blobs domain.BlobStore
+	done  domain.Status
 }
 
-func NewTodoRepository(db *gorm.DB) domain.TodoRepository {
-	db.AutoMigrate(&models.Todo{})
+// NewTodoRepository creates the todo repository. blobs holds the content of
+// attachments, which is removed together with the todos they belong to.
+// Todos moving to the done state of workflow get a completion time.
+func NewTodoRepository(db *gorm.DB, blobs domain.BlobStore, workflow *domain.Workflow) domain.TodoRepository {
+	db.AutoMigrate(&models.Todo{}, &models.TodoDependency{}, &models.TodoEvent{}, &models.TodoLink{})
 	return &todoRepository{
-		db: db,
+		db:    db,
+		blobs: blobs,
+		done:  workflow.Done,
 	}
 }
 
 func (r *todoRepository) Create(todo *domain.Todo) error {
 	dbTodo := models.FromDomain(todo)
-	if err := r.db.Create(dbTodo).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return createTodo(tx, dbTodo, r.done)
+	})
+	if err != nil {
 		return err
 	}
 	*todo = *dbTodo.ToDomain()
@@ -28,49 +88,951 @@ func (r *todoRepository) Create(todo *domain.Todo) error {
 
 func (r *todoRepository) GetByID(id uint) (*domain.Todo, error) {
 	var dbTodo models.Todo
-	err := r.db.First(&dbTodo, id).Error
+	err := preloadTodo(r.db).First(&dbTodo, id).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, domain.ErrNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
-	return dbTodo.ToDomain(), nil
+	todos, err := r.toDomainTodos([]models.Todo{dbTodo})
+	if err != nil {
+		return nil, err
+	}
+	return todos[0], nil
 }
 
-func (r *todoRepository) GetAll() ([]*domain.Todo, error) {
+// GetAll pages through todos by their sort keys rather than by offset: a
+// page starts right after the keys of the last todo of the previous page.
+// The IDs and keys of a page are read first, then the todos themselves.
+func (r *todoRepository) GetAll(filter domain.TodoFilter, page domain.PageRequest) (*domain.TodoPage, error) {
+	order, keys, err := todoOrder(filter)
+	if err != nil {
+		return nil, err
+	}
+	query := applyTodoFilter(selectSortKeys(r.db.Model(&models.Todo{}), keys), filter)
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor, order, keys)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(afterKeys(keys, after))
+	}
+
+	// One todo more than requested tells whether there is a next page.
+	rows, err := query.Order(orderBy(keys)).Limit(page.Limit + 1).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint
+	var lastKeys []*string
+	more := false
+	for rows.Next() {
+		var id uint
+		values := make([]*string, len(keys))
+		dest := []any{&id}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if len(ids) == page.Limit {
+			more = true
+			break
+		}
+		ids = append(ids, id)
+		lastKeys = values
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	var dbTodos []models.Todo
-	if err := r.db.Find(&dbTodos).Error; err != nil {
+	if err := preloadTodo(r.db).Where("id IN ?", ids).Find(&dbTodos).Error; err != nil {
+		return nil, err
+	}
+	// Keep the order of the page; a todo deleted in the meantime is left out.
+	index := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	sort.Slice(dbTodos, func(i, j int) bool {
+		return index[dbTodos[i].ID] < index[dbTodos[j].ID]
+	})
+	todos, err := r.toDomainTodos(dbTodos)
+	if err != nil {
 		return nil, err
 	}
 
-	todos := make([]*domain.Todo, len(dbTodos))
-	for i, dbTodo := range dbTodos {
-		todos[i] = dbTodo.ToDomain()
+	result := &domain.TodoPage{Todos: todos}
+	if more {
+		result.NextCursor = encodeCursor(order, lastKeys)
 	}
-	return todos, nil
+	return result, nil
 }
 
-func (r *todoRepository) Update(todo *domain.Todo) error {
-	dbTodo := models.FromDomain(todo)
-	result := r.db.Save(dbTodo)
+func (r *todoRepository) GetChildren(id uint) ([]*domain.Todo, error) {
+	var dbTodos []models.Todo
+	err := preloadTodo(r.db).Where("parent_id = ?", id).Order(positionColumn).Order("id").Find(&dbTodos).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainTodos(dbTodos)
+}
+
+func (r *todoRepository) SubtreeHeight(id uint) (int, error) {
+	var height int
+	err := r.db.Raw(`
+WITH RECURSIVE subtree AS (
+	SELECT id, 0 AS depth FROM todos WHERE id = ?
+	UNION ALL
+	SELECT t.id, s.depth + 1 FROM todos t JOIN subtree s ON t.parent_id = s.id WHERE s.depth < ?
+)
+SELECT COALESCE(MAX(depth), 0) FROM subtree`, id, maxTreeWalk).Scan(&height).Error
+	return height, err
+}
+
+func applyTodoFilter(db *gorm.DB, filter domain.TodoFilter) *gorm.DB {
+	if filter.DueAfter != nil {
+		db = db.Where("due_at >= ?", *filter.DueAfter)
+	}
+	if filter.DueBefore != nil {
+		db = db.Where("due_at < ?", *filter.DueBefore)
+	}
+	if filter.ProjectID != nil {
+		db = db.Where("project_id = ?", *filter.ProjectID)
+	}
+	if len(filter.Statuses) > 0 {
+		db = db.Where("status IN ?", filter.Statuses)
+	}
+	switch filter.Archived {
+	case domain.ArchivedInclude:
+	case domain.ArchivedOnly:
+		db = db.Where("archived_at IS NOT NULL")
+	default:
+		db = db.Where("archived_at IS NULL")
+	}
+	if len(filter.IDs) > 0 {
+		db = db.Where("id IN ?", filter.IDs)
+	}
+	if filter.Completed != nil {
+		db = db.Where("completed = ?", *filter.Completed)
+	}
+	if filter.Title != "" {
+		db = db.Where(`title ILIKE ? ESCAPE '\'`, containsPattern(filter.Title))
+	}
+	if filter.Description != "" {
+		db = db.Where(`description ILIKE ? ESCAPE '\'`, containsPattern(filter.Description))
+	}
+	if len(filter.SearchTerms) > 0 {
+		db = db.Where("search_vector @@ (?)", searchTSQuery(filter.SearchTerms))
+	}
+	if filter.CreatedAfter != nil {
+		db = db.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		db = db.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.UpdatedAfter != nil {
+		db = db.Where("updated_at >= ?", *filter.UpdatedAfter)
+	}
+	if filter.UpdatedBefore != nil {
+		db = db.Where("updated_at < ?", *filter.UpdatedBefore)
+	}
+	if filter.CompletedAfter != nil {
+		db = db.Where("completed_at >= ?", *filter.CompletedAfter)
+	}
+	if filter.CompletedBefore != nil {
+		db = db.Where("completed_at < ?", *filter.CompletedBefore)
+	}
+	if filter.OnlyOpen {
+		db = db.Where("completed = ?", false)
+	}
+	if filter.Actionable {
+		db = db.Where("completed = ?", false).Where("NOT " + openBlockerQuery)
+	}
+	if len(filter.Priorities) > 0 {
+		ranks := make([]int, len(filter.Priorities))
+		for i, p := range filter.Priorities {
+			ranks[i], _ = p.Rank()
+		}
+		db = db.Where("priority IN ?", ranks)
+	}
+	if filter.MinPriority != "" {
+		rank, _ := filter.MinPriority.Rank()
+		db = db.Where("priority >= ?", rank)
+	}
+	if len(filter.Tags) > 0 {
+		tagged := db.Session(&gorm.Session{NewDB: true}).
+			Table("todo_tags").
+			Select("todo_tags.todo_id").
+			Joins("JOIN tags ON tags.id = todo_tags.tag_id").
+			Where("tags.name IN ?", filter.Tags)
+		if filter.TagMatch == domain.TagMatchAll {
+			tagged = tagged.Group("todo_tags.todo_id").
+				Having("COUNT(DISTINCT tags.id) = ?", len(filter.Tags))
+		}
+		db = db.Where("id IN (?)", tagged)
+	}
+	for _, condition := range filter.CustomFieldConditions {
+		// Containment compares typed JSON values and can use the GIN index.
+		value, _ := json.Marshal(map[string]any{condition.Key: condition.Value})
+		db = db.Where("custom_fields @> ?::jsonb", string(value))
+	}
+	return db
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns a LIKE pattern matching text anywhere.
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+func (r *todoRepository) LastPosition() (string, error) {
+	var position string
+	err := r.db.Model(&models.Todo{}).Select("COALESCE(MAX(" + positionColumn + "), '')").Scan(&position).Error
+	return position, err
+}
+
+func (r *todoRepository) AdjacentPositions(targetID, excludeID uint, after bool) (string, string, error) {
+	var target models.Todo
+	err := r.db.Select("id", "position").First(&target, targetID).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", "", domain.ErrNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	query := r.db.Model(&models.Todo{}).Select("position").Where("id <> ?", excludeID).Limit(1)
+	if after {
+		query = query.Where("("+positionColumn+", id) > (?, ?)", target.Position, target.ID).
+			Order(positionColumn).Order("id")
+	} else {
+		query = query.Where("("+positionColumn+", id) < (?, ?)", target.Position, target.ID).
+			Order(positionColumn + " DESC").Order("id DESC")
+	}
+	var neighbours []string
+	if err := query.Pluck("position", &neighbours).Error; err != nil {
+		return "", "", err
+	}
+	if len(neighbours) == 0 {
+		return target.Position, "", nil
+	}
+	return target.Position, neighbours[0], nil
+}
+
+func (r *todoRepository) SetPosition(id uint, position string) error {
+	result := r.db.Model(&models.Todo{ID: id}).Update("position", position)
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
 		return domain.ErrNotFound
 	}
-	*todo = *dbTodo.ToDomain()
 	return nil
 }
 
-func (r *todoRepository) Delete(id uint) error {
-	result := r.db.Delete(&models.Todo{}, id)
+// RebalancePositions locks the todos table against concurrent writes, so no
+// todo is created or moved while positions are reassigned.
+func (r *todoRepository) RebalancePositions() error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE todos IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		// Deleted todos are included so that they keep their place in
+		// the order when they are restored.
+		var ids []uint
+		err := tx.Unscoped().Model(&models.Todo{}).Order(positionColumn).Order("id").Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+
+		positions := domain.SpreadPositions(len(ids))
+		for start := 0; start < len(ids); start += rebalanceBatchSize {
+			end := min(start+rebalanceBatchSize, len(ids))
+			values := make([]string, 0, end-start)
+			args := make([]any, 0, 2*(end-start))
+			for i := start; i < end; i++ {
+				values = append(values, "(?::integer, ?)")
+				args = append(args, ids[i], positions[i])
+			}
+			err := tx.Exec(`
+UPDATE todos SET position = v.position
+FROM (VALUES `+strings.Join(values, ", ")+`) AS v (id, position)
+WHERE todos.id = v.id`, args...).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateChecklist locks the todo row while update rewrites the checklist, so
+// concurrent changes to the same checklist are applied one after another.
+func (r *todoRepository) UpdateChecklist(
+	id uint,
+	update func(checklist []domain.ChecklistItem) ([]domain.ChecklistItem, error),
+) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var dbTodo models.Todo
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "checklist").First(&dbTodo, id).Error
+		if err == gorm.ErrRecordNotFound {
+			return domain.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		checklist, err := update(dbTodo.Checklist)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&dbTodo).Update("checklist", models.Checklist(checklist)).Error
+	})
+}
+
+func (r *todoRepository) GetBlockers(id uint) ([]*domain.Todo, error) {
+	var dbTodos []models.Todo
+	err := preloadTodo(r.db).
+		Where("id IN (?)", r.db.Model(&models.TodoDependency{}).Select("blocked_by_id").Where("todo_id = ?", id)).
+		Order("id").
+		Find(&dbTodos).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainTodos(dbTodos)
+}
+
+func (r *todoRepository) AddBlocker(id, blockerID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.TodoDependency{TodoID: id, BlockedByID: blockerID}).Error
+}
+
+func (r *todoRepository) RemoveBlocker(id, blockerID uint) error {
+	result := r.db.Where("todo_id = ? AND blocked_by_id = ?", id, blockerID).Delete(&models.TodoDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *todoRepository) DependsOn(id, otherID uint) (bool, error) {
+	var found bool
+	err := r.db.Raw("SELECT EXISTS (SELECT 1 FROM ("+blockersQuery+") blockers WHERE id = ?)", id, otherID).
+		Scan(&found).Error
+	return found, err
+}
+
+func (r *todoRepository) GetHistory(id uint) ([]*domain.TodoEvent, error) {
+	var dbEvents []models.TodoEvent
+	if err := r.db.Where("todo_id = ?", id).Order("created_at").Order("id").Find(&dbEvents).Error; err != nil {
+		return nil, err
+	}
+	events := make([]*domain.TodoEvent, len(dbEvents))
+	for i := range dbEvents {
+		events[i] = dbEvents[i].ToDomain()
+	}
+	return events, nil
+}
+
+func (r *todoRepository) ExistingIDs(ids []uint) ([]uint, error) {
+	var existing []uint
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	err := r.db.Model(&models.Todo{}).Where("id IN ?", ids).Pluck("id", &existing).Error
+	return existing, err
+}
+
+func (r *todoRepository) SetArchived(id uint, archivedAt *time.Time) error {
+	result := r.db.Model(&models.Todo{ID: id}).Update("archived_at", archivedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *todoRepository) ArchiveCompleted(projectID uint, archivedAt time.Time) (int, error) {
+	result := r.db.Model(&models.Todo{}).
+		Where("project_id = ? AND completed AND archived_at IS NULL", projectID).
+		Update("archived_at", archivedAt)
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *todoRepository) GetLinks(id uint) ([]domain.TodoLink, error) {
+	var links []domain.TodoLink
+	err := r.db.Table("todo_links l").
+		Select("l.type, l.linked_todo_id AS todo_id, t.title, t.status").
+		Joins("JOIN todos t ON t.id = l.linked_todo_id AND t.deleted_at IS NULL").
+		Where("l.todo_id = ?", id).
+		Order("l.type").Order("l.linked_todo_id").
+		Scan(&links).Error
+	return links, err
+}
+
+func (r *todoRepository) AddLink(id uint, link domain.TodoLink, closed *domain.Todo) error {
+	inverse, _ := link.Type.Inverse()
+	dbLinks := []models.TodoLink{
+		{TodoID: id, LinkedTodoID: link.TodoID, Type: string(link.Type)},
+		{TodoID: link.TodoID, LinkedTodoID: id, Type: string(inverse)},
+	}
+	var dbTodo *models.Todo
+	if closed != nil {
+		dbTodo = models.FromDomain(closed)
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var linked int64
+		err := tx.Model(&models.TodoLink{}).
+			Where("todo_id = ? AND linked_todo_id = ?", id, link.TodoID).
+			Count(&linked).Error
+		if err != nil {
+			return err
+		}
+		if linked > 0 {
+			return domain.ErrLinkExists
+		}
+		for _, dbLink := range dbLinks {
+			if dbLink.Type != string(domain.LinkDuplicateOf) {
+				continue
+			}
+			// GetLinks leaves out todos in the trash, so the error says
+			// when the duplicate is one of those.
+			var canonical models.Todo
+			err := tx.Unscoped().
+				Select("todos.id", "todos.deleted_at").
+				Joins("JOIN todo_links l ON l.linked_todo_id = todos.id").
+				Where("l.todo_id = ? AND l.type = ?", dbLink.TodoID, domain.LinkDuplicateOf).
+				Take(&canonical).Error
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if canonical.DeletedAt.Valid {
+				return fmt.Errorf("%w: of todo %d, which is in the trash", domain.ErrAlreadyDuplicate, canonical.ID)
+			}
+			return domain.ErrAlreadyDuplicate
+		}
+
+		if err := tx.Create(dbLinks).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return domain.ErrLinkExists
+			}
+			return err
+		}
+		if dbTodo == nil {
+			return nil
+		}
+		return updateTodo(tx, dbTodo, r.done)
+	})
+	if err != nil || dbTodo == nil {
+		return err
+	}
+	todos, err := r.toDomainTodos([]models.Todo{*dbTodo})
+	if err != nil {
+		return err
+	}
+	*closed = *todos[0]
+	return nil
+}
+
+func (r *todoRepository) RemoveLink(id, linkedID uint) error {
+	result := r.db.
+		Where("(todo_id = ? AND linked_todo_id = ?) OR (todo_id = ? AND linked_todo_id = ?)", id, linkedID, linkedID, id).
+		Delete(&models.TodoLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *todoRepository) CanonicalID(id uint) (uint, error) {
+	var canonicalID uint
+	err := r.db.Raw(`
+WITH RECURSIVE chain AS (
+	SELECT ?::integer AS id, 0 AS depth
+	UNION ALL
+	SELECT l.linked_todo_id, c.depth + 1 FROM todo_links l JOIN chain c ON l.todo_id = c.id
+	WHERE l.type = ? AND c.depth < ?
+)
+SELECT id FROM chain ORDER BY depth DESC LIMIT 1`, id, domain.LinkDuplicateOf, maxTreeWalk).Scan(&canonicalID).Error
+	return canonicalID, err
+}
+
+func (r *todoRepository) Update(todo *domain.Todo) error {
+	dbTodo := models.FromDomain(todo)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return updateTodo(tx, dbTodo, r.done)
+	})
+	if err != nil {
+		return err
+	}
+	todos, err := r.toDomainTodos([]models.Todo{*dbTodo})
+	if err != nil {
+		return err
+	}
+	*todo = *todos[0]
+	return nil
+}
+
+// CompleteOccurrence stores the completed occurrence of a recurring todo and
+// creates the next one in a single transaction, linking the two.
+func (r *todoRepository) CompleteOccurrence(todo, next *domain.Todo) error {
+	dbTodo := models.FromDomain(todo)
+	dbNext := models.FromDomain(next)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := createTodo(tx, dbNext, r.done); err != nil {
+			return err
+		}
+		if err := copyOffsetReminders(tx, dbTodo.ID, dbNext.ID); err != nil {
+			return err
+		}
+		dbTodo.NextOccurrenceID = &dbNext.ID
+		return updateTodo(tx, dbTodo, r.done)
+	})
+	if err != nil {
+		return err
+	}
+	todos, err := r.toDomainTodos([]models.Todo{*dbTodo})
+	if err != nil {
+		return err
+	}
+	*todo = *todos[0]
+	*next = *dbNext.ToDomain()
+	return nil
+}
+
+// createTodo inserts a todo, recording its completion if it starts out
+// done, or its closing if it starts out in another closed state.
+func createTodo(tx *gorm.DB, dbTodo *models.Todo, done domain.Status) error {
+	dbTodo.CompletedAt = nil
+	now := time.Now()
+	outcome := todoOutcome(dbTodo, done)
+	if outcome == domain.TodoEventCompleted {
+		dbTodo.CompletedAt = &now
+	}
+	if err := tx.Omit(clause.Associations).Create(dbTodo).Error; err != nil {
+		return err
+	}
+	if outcome != domain.TodoEventReopened {
+		if err := recordTodoEvent(tx, dbTodo, outcome, now); err != nil {
+			return err
+		}
+	}
+	return replaceTodoTags(tx, dbTodo.ID, dbTodo.Tags)
+}
+
+// updateTodo overwrites every column of a todo except its ID, creation time,
+// checklist, position and archive time, moves its offset reminders along with
+// the due date and reloads it so that dbTodo reflects the stored row. The
+// checklist, position and archive time are only changed through
+// UpdateChecklist, SetPosition and SetArchived so that concurrent edits are
+// not lost.
+//
+// The completion time is kept unless the todo is completed, closed or
+// reopened, which is compared against the locked row and recorded in its
+// history. Only todos in the done state have a completion time.
+func updateTodo(tx *gorm.DB, dbTodo *models.Todo, done domain.Status) error {
+	var stored models.Todo
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "status", "completed", "completed_at").
+		First(&stored, dbTodo.ID).Error
+	if err == gorm.ErrRecordNotFound {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	dbTodo.CompletedAt = stored.CompletedAt
+	if outcome := todoOutcome(dbTodo, done); outcome != todoOutcome(&stored, done) {
+		now := time.Now()
+		dbTodo.CompletedAt = nil
+		if outcome == domain.TodoEventCompleted {
+			dbTodo.CompletedAt = &now
+		}
+		if err := recordTodoEvent(tx, dbTodo, outcome, now); err != nil {
+			return err
+		}
+	}
+
+	result := tx.Model(dbTodo).
+		Select("*").
+		Omit("id", "created_at", "deleted_at", "checklist", "position", "archived_at", clause.Associations).
+		Updates(dbTodo)
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
 		return domain.ErrNotFound
 	}
+	if err := replaceTodoTags(tx, dbTodo.ID, dbTodo.Tags); err != nil {
+		return err
+	}
+	if err := rescheduleReminders(tx, dbTodo.ID); err != nil {
+		return err
+	}
+	return preloadTodo(tx).First(dbTodo, dbTodo.ID).Error
+}
+
+// todoOutcome tells whether a todo is open, done or closed otherwise, as
+// the event its history records for a todo moving there.
+func todoOutcome(dbTodo *models.Todo, done domain.Status) domain.TodoEventType {
+	switch {
+	case !dbTodo.Completed:
+		return domain.TodoEventReopened
+	case dbTodo.Status == string(done):
+		return domain.TodoEventCompleted
+	}
+	return domain.TodoEventClosed
+}
+
+// recordTodoEvent adds the completion, closing or reopening of a todo to its
+// history.
+func recordTodoEvent(tx *gorm.DB, dbTodo *models.Todo, eventType domain.TodoEventType, at time.Time) error {
+	return tx.Create(&models.TodoEvent{
+		TodoID:    dbTodo.ID,
+		Type:      string(eventType),
+		Status:    dbTodo.Status,
+		CreatedAt: at,
+	}).Error
+}
+
+// Delete moves a todo to the trash. Its subtasks are either moved up to the
+// todo's own parent or, with ChildrenCascade, deleted together with it. Rows
+// that belong to the todo, such as comments and attachments, are kept until
+// it is purged.
+func (r *todoRepository) Delete(id uint, children domain.ChildPolicy) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var dbTodo models.Todo
+		err := tx.Select("id", "parent_id").First(&dbTodo, id).Error
+		if err == gorm.ErrRecordNotFound {
+			return domain.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		ids := []uint{id}
+		if children == domain.ChildrenCascade {
+			if err := tx.Raw(subtreeQuery, id).Scan(&ids).Error; err != nil {
+				return err
+			}
+		} else {
+			err := tx.Model(&models.Todo{}).
+				Where("parent_id = ?", id).
+				Update("parent_id", dbTodo.ParentID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// A single statement gives the whole subtree the same deletion time,
+		// which is how Restore and Purge find the todos deleted together.
+		return tx.Delete(&models.Todo{}, ids).Error
+	})
+}
+
+func (r *todoRepository) GetTrash() ([]*domain.Todo, error) {
+	var dbTodos []models.Todo
+	err := preloadTodo(r.db.Unscoped()).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").Order("id").
+		Find(&dbTodos).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainTodos(dbTodos)
+}
+
+// Restore refuses to bring back a subtask whose parent is still in the
+// trash, since it would hang below a todo that is not listed.
+func (r *todoRepository) Restore(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		dbTodo, err := trashedTodo(tx, id)
+		if err != nil {
+			return err
+		}
+		if dbTodo.ParentID != nil {
+			var parents int64
+			if err := tx.Model(&models.Todo{}).Where("id = ?", *dbTodo.ParentID).Count(&parents).Error; err != nil {
+				return err
+			}
+			if parents == 0 {
+				return domain.ErrParentTrashed
+			}
+		}
+		return deletedTogether(tx, dbTodo).Update("deleted_at", nil).Error
+	})
+}
+
+func (r *todoRepository) Purge(id uint) error {
+	var blobKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		dbTodo, err := trashedTodo(tx, id)
+		if err != nil {
+			return err
+		}
+		var ids []uint
+		if err := deletedTogether(tx, dbTodo).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		blobKeys, err = deleteTodos(tx, ids)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	removeBlobs(r.blobs, blobKeys)
+	return nil
+}
+
+// PurgeDeletedBefore removes the todos in batches, each in a transaction of
+// its own, so a large trash does not hold locks for long.
+func (r *todoRepository) PurgeDeletedBefore(before time.Time) (int, error) {
+	purged := 0
+	for {
+		var ids []uint
+		var blobKeys []string
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Unscoped().Model(&models.Todo{}).
+				Where("deleted_at < ?", before).
+				Order("id").
+				Limit(purgeBatchSize).
+				Pluck("id", &ids).Error
+			if err != nil || len(ids) == 0 {
+				return err
+			}
+			blobKeys, err = deleteTodos(tx, ids)
+			return err
+		})
+		if err != nil {
+			return purged, err
+		}
+		removeBlobs(r.blobs, blobKeys)
+		purged += len(ids)
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// trashedTodo loads the deleted todo id.
+func trashedTodo(tx *gorm.DB, id uint) (*models.Todo, error) {
+	var dbTodo models.Todo
+	err := tx.Unscoped().
+		Select("id", "parent_id", "deleted_at").
+		Where("deleted_at IS NOT NULL").
+		First(&dbTodo, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dbTodo, nil
+}
+
+// deletedTogether selects the deleted todo dbTodo and the subtasks that were
+// deleted in the same statement.
+func deletedTogether(tx *gorm.DB, dbTodo *models.Todo) *gorm.DB {
+	return tx.Unscoped().Model(&models.Todo{}).
+		Where("id IN ("+subtreeQuery+")", dbTodo.ID).
+		Where("deleted_at = ?", dbTodo.DeletedAt)
+}
+
+// deleteTodos permanently removes todos, whether in the trash or not,
+// together with the rows that depend on them.
+// Subtasks outside of ids lose their parent instead of being deleted.
+//
+// It returns the storage keys of the removed attachments; the caller deletes
+// those blobs once the transaction has committed.
+func deleteTodos(tx *gorm.DB, ids []uint) ([]string, error) {
+	err := tx.Unscoped().Model(&models.Todo{}).
+		Where("parent_id IN ? AND id NOT IN ?", ids, ids).
+		Update("parent_id", nil).Error
+	if err != nil {
+		return nil, err
+	}
+	err = tx.Unscoped().Model(&models.Todo{}).
+		Where("next_occurrence_id IN ? AND id NOT IN ?", ids, ids).
+		Update("next_occurrence_id", nil).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Exec("DELETE FROM todo_tags WHERE todo_id IN ?", ids).Error; err != nil {
+		return nil, err
+	}
+	err = tx.Where("todo_id IN ? OR blocked_by_id IN ?", ids, ids).Delete(&models.TodoDependency{}).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Where("todo_id IN ?", ids).Delete(&models.TimeEntry{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("todo_id IN ?", ids).Delete(&models.Comment{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("todo_id IN ?", ids).Delete(&models.Reminder{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("todo_id IN ?", ids).Delete(&models.TodoEvent{}).Error; err != nil {
+		return nil, err
+	}
+	err = tx.Where("todo_id IN ? OR linked_todo_id IN ?", ids, ids).Delete(&models.TodoLink{}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var blobKeys []string
+	err = tx.Model(&models.Attachment{}).Where("todo_id IN ?", ids).Pluck("storage_key", &blobKeys).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Where("todo_id IN ?", ids).Delete(&models.Attachment{}).Error; err != nil {
+		return nil, err
+	}
+
+	return blobKeys, tx.Unscoped().Delete(&models.Todo{}, ids).Error
+}
+
+// removeBlobs deletes blobs whose attachments are gone. Failures only leave
+// unreferenced files behind, so they are logged rather than returned.
+func removeBlobs(blobs domain.BlobStore, keys []string) {
+	for _, key := range keys {
+		if err := blobs.Delete(key); err != nil {
+			log.Printf("failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// toDomainTodos converts todos and attaches the aggregates that are part of
+// a todo response: the progress of their direct subtasks, their comment count,
+// whether they are blocked and the time tracked on them. Progress counts the
+// subtasks in the done state out of those that are open or done; subtasks
+// closed otherwise, e.g. cancelled, are left out.
+func (r *todoRepository) toDomainTodos(dbTodos []models.Todo) ([]*domain.Todo, error) {
+	todos := make([]*domain.Todo, len(dbTodos))
+	if len(dbTodos) == 0 {
+		return todos, nil
+	}
+
+	ids := make([]uint, len(dbTodos))
+	for i, dbTodo := range dbTodos {
+		todos[i] = dbTodo.ToDomain()
+		ids[i] = dbTodo.ID
+	}
+
+	var rows []struct {
+		ParentID uint
+		Done     int
+		Total    int
+	}
+	err := r.db.Model(&models.Todo{}).
+		Select("parent_id, COUNT(*) FILTER (WHERE status = ?) AS done, COUNT(*) FILTER (WHERE NOT completed OR status = ?) AS total", r.done, r.done).
+		Where("parent_id IN ?", ids).
+		Group("parent_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make(map[uint]*domain.Progress, len(rows))
+	for _, row := range rows {
+		if row.Total > 0 {
+			progress[row.ParentID] = &domain.Progress{Done: row.Done, Total: row.Total}
+		}
+	}
+	var commentRows []struct {
+		TodoID uint
+		Count  int
+	}
+	err = r.db.Model(&models.Comment{}).
+		Select("todo_id, COUNT(*) AS count").
+		Where("todo_id IN ?", ids).
+		Group("todo_id").
+		Scan(&commentRows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make(map[uint]int, len(commentRows))
+	for _, row := range commentRows {
+		comments[row.TodoID] = row.Count
+	}
+
+	var blockedIDs []uint
+	err = r.db.Model(&models.Todo{}).Where("id IN ?", ids).Where(openBlockerQuery).Pluck("id", &blockedIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make(map[uint]bool, len(blockedIDs))
+	for _, id := range blockedIDs {
+		blocked[id] = true
+	}
+
+	var trackedRows []struct {
+		TodoID  uint
+		Seconds float64
+	}
+	err = r.db.Model(&models.TimeEntry{}).
+		Select("todo_id, SUM(EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)) AS seconds").
+		Where("todo_id IN ?", ids).
+		Group("todo_id").
+		Scan(&trackedRows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make(map[uint]int, len(trackedRows))
+	for _, row := range trackedRows {
+		tracked[row.TodoID] = int(row.Seconds / 60)
+	}
+
+	for _, todo := range todos {
+		todo.Progress = progress[todo.ID]
+		todo.CommentCount = comments[todo.ID]
+		todo.Blocked = blocked[todo.ID]
+		todo.TrackedMinutes = tracked[todo.ID]
+	}
+	return todos, nil
+}
+
+// preloadTodo loads the associations that are part of a todo response.
+func preloadTodo(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tags.name")
+	})
+}
+
+// replaceTodoTags makes tags the exact set of tags assigned to a todo.
+func replaceTodoTags(tx *gorm.DB, todoID uint, tags []models.Tag) error {
+	if err := tx.Exec("DELETE FROM todo_tags WHERE todo_id = ?", todoID).Error; err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		err := tx.Exec("INSERT INTO todo_tags (todo_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", todoID, tag.ID).Error
+		if err != nil {
+			return err
+		}
+	}
 	return nil
-} 
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"testing"
+
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+)
+
+func TestTodoOutcome(t *testing.T) {
+	tests := []struct {
+		todo models.Todo
+		want domain.TodoEventType
+	}{
+		{models.Todo{Status: "open"}, domain.TodoEventReopened},
+		{models.Todo{Status: "in_review"}, domain.TodoEventReopened},
+		{models.Todo{Status: "done", Completed: true}, domain.TodoEventCompleted},
+		{models.Todo{Status: "cancelled", Completed: true}, domain.TodoEventClosed},
+		{models.Todo{Status: "shipped", Completed: true}, domain.TodoEventClosed},
+	}
+	for _, tt := range tests {
+		if got := todoOutcome(&tt.todo, domain.StatusDone); got != tt.want {
+			t.Errorf("todoOutcome(%q, completed %v) = %q, want %q", tt.todo.Status, tt.todo.Completed, got, tt.want)
+		}
+	}
+
+	// With another done state, moving to the default one only closes the
+	// todo.
+	if got := todoOutcome(&models.Todo{Status: "done", Completed: true}, "shipped"); got != domain.TodoEventClosed {
+		t.Errorf("todoOutcome() with done state shipped = %q, want %q", got, domain.TodoEventClosed)
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"html"
+	"strings"
+
+	"go-todo-api/internal/domain"
+	"go-todo-api/internal/repository/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// searchConfig is the text search configuration the search_vector column is
+// built with; queries have to use the same one.
+const searchConfig = "english"
+
+// Matches are marked with characters from the Unicode private use area,
+// which do not occur in ordinary text, so that the highlighted text can be
+// HTML escaped before the marks are turned into <mark> tags.
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+var (
+	titleHighlightOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", HighlightAll=true"
+	snippetOptions        = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + `, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+	highlightReplacer     = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+)
+
+// Search ranks the todos whose search_vector matches the query with
+// ts_rank_cd. Highlights are only computed for the todos that make it into
+// the results, since ts_headline has to parse the whole text again.
+func (r *todoRepository) Search(query domain.SearchQuery) ([]*domain.SearchResult, error) {
+	tsquery := searchTSQuery(query.Terms)
+	hits := r.db.Table("todos t").
+		Select("t.id, t.title, t.description, ts_rank_cd(t.search_vector, q.query) AS rank").
+		Joins("CROSS JOIN (SELECT ? AS query) q", tsquery).
+		Where("t.search_vector @@ q.query").
+		Where("t.deleted_at IS NULL")
+	if query.ProjectID != nil {
+		hits = hits.Where("t.project_id = ?", *query.ProjectID)
+	}
+	hits = hits.Order("rank DESC").Order("t.id").Limit(query.Limit)
+
+	var rows []struct {
+		ID             uint
+		Rank           float64
+		TitleHighlight string
+		Snippet        string
+	}
+	err := r.db.Raw(`
+SELECT h.id, h.rank,
+	ts_headline(?::regconfig, h.title, q.query, ?) AS title_highlight,
+	CASE WHEN coalesce(h.description, '') = '' THEN '' ELSE ts_headline(?::regconfig, h.description, q.query, ?) END AS snippet
+FROM (?) h CROSS JOIN (SELECT ? AS query) q
+ORDER BY h.rank DESC, h.id`,
+		searchConfig, titleHighlightOptions, searchConfig, snippetOptions, hits, tsquery,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var dbTodos []models.Todo
+	if err := preloadTodo(r.db).Where("id IN ?", ids).Find(&dbTodos).Error; err != nil {
+		return nil, err
+	}
+	todos, err := r.toDomainTodos(dbTodos)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*domain.Todo, len(todos))
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+	}
+
+	results := make([]*domain.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		todo, ok := byID[row.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, &domain.SearchResult{
+			Todo:           todo,
+			Rank:           row.Rank,
+			TitleHighlight: highlightHTML(row.TitleHighlight),
+			Snippet:        highlightHTML(row.Snippet),
+		})
+	}
+	return results, nil
+}
+
+// searchTSQuery combines the terms into a tsquery. Words and phrases go
+// through plainto_tsquery and phraseto_tsquery, which take any text;
+// prefixes, which the domain restricts to letters and digits, through
+// to_tsquery with :*.
+func searchTSQuery(terms []domain.SearchTerm) clause.Expr {
+	var sql []string
+	var vars []any
+	for _, term := range terms {
+		part := "plainto_tsquery(?::regconfig, ?)"
+		text := term.Text
+		switch {
+		case term.Phrase:
+			part = "phraseto_tsquery(?::regconfig, ?)"
+		case term.Prefix:
+			part = "to_tsquery(?::regconfig, ?)"
+			text += ":*"
+		}
+		if term.Exclude {
+			part = "!!" + part
+		}
+		sql = append(sql, "("+part+")")
+		vars = append(vars, searchConfig, text)
+	}
+	return clause.Expr{SQL: strings.Join(sql, " && "), Vars: vars}
+}
+
+// highlightHTML escapes text as HTML and turns the marks of ts_headline into
+// <mark> tags.
+func highlightHTML(text string) string {
+	return highlightReplacer.Replace(html.EscapeString(text))
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"time"
+
+	"go-todo-api/internal/domain"
+)
+
+// Archive archives a todo. Archiving an archived todo keeps its original
+// archive time.
+func (u *todoUsecase) Archive(id uint) (*domain.Todo, error) {
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if todo.ArchivedAt == nil {
+		now := time.Now()
+		if err := u.todoRepo.SetArchived(id, &now); err != nil {
+			return nil, err
+		}
+		todo.Archived = true
+		todo.ArchivedAt = &now
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return todo, nil
+}
+
+func (u *todoUsecase) Unarchive(id uint) (*domain.Todo, error) {
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if todo.ArchivedAt != nil {
+		if err := u.todoRepo.SetArchived(id, nil); err != nil {
+			return nil, err
+		}
+		todo.Archived = false
+		todo.ArchivedAt = nil
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return todo, nil
+}
+
+func (u *todoUsecase) ArchiveCompleted(projectID uint) (int, error) {
+	if _, err := u.projectRepo.GetByID(projectID); err != nil {
+		return 0, err
+	}
+	return u.todoRepo.ArchiveCompleted(projectID, time.Now())
+}
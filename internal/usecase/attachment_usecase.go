@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"go-todo-api/internal/domain"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxAttachmentFilenameLength    = 255
+	maxAttachmentContentTypeLength = 255
+)
+
+type attachmentUsecase struct {
+	attachmentRepo domain.AttachmentRepository
+	todoRepo       domain.TodoRepository
+	blobs          domain.BlobStore
+	maxSize        int64
+}
+
+// NewAttachmentUsecase creates the attachment usecase. Uploads larger than
+// maxSize bytes are rejected.
+func NewAttachmentUsecase(
+	repo domain.AttachmentRepository,
+	todoRepo domain.TodoRepository,
+	blobs domain.BlobStore,
+	maxSize int64,
+) domain.AttachmentUsecase {
+	return &attachmentUsecase{
+		attachmentRepo: repo,
+		todoRepo:       todoRepo,
+		blobs:          blobs,
+		maxSize:        maxSize,
+	}
+}
+
+// Upload streams content into the blob store while computing its size and
+// SHA-256 checksum, then records the attachment's metadata.
+func (u *attachmentUsecase) Upload(attachment *domain.Attachment, content io.Reader) error {
+	if _, err := u.todoRepo.GetByID(attachment.TodoID); err != nil {
+		return err
+	}
+	attachment.Filename = cleanFilename(attachment.Filename)
+	attachment.ContentType = cleanContentType(attachment.ContentType)
+
+	key, err := newBlobKey()
+	if err != nil {
+		return err
+	}
+
+	// Reading one byte past the limit is enough to tell that the upload is
+	// too large without consuming the rest of it.
+	body := bufio.NewReader(io.LimitReader(content, u.maxSize+1))
+	if attachment.ContentType == "" || attachment.ContentType == "application/octet-stream" {
+		head, _ := body.Peek(512)
+		attachment.ContentType = http.DetectContentType(head)
+	}
+
+	hash := sha256.New()
+	size, err := u.blobs.Put(key, io.TeeReader(body, hash))
+	if err != nil {
+		u.removeBlob(key)
+		return err
+	}
+	if size > u.maxSize {
+		u.removeBlob(key)
+		return domain.ErrAttachmentTooLarge
+	}
+
+	attachment.ID = 0
+	attachment.Size = size
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+	attachment.StorageKey = key
+	if err := u.attachmentRepo.Create(attachment); err != nil {
+		u.removeBlob(key)
+		return err
+	}
+	return nil
+}
+
+// GetByID returns an attachment of a todo. The attachments of a todo in the
+// trash are not found, like the todo itself.
+func (u *attachmentUsecase) GetByID(todoID, id uint) (*domain.Attachment, error) {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return nil, err
+	}
+	attachment, err := u.attachmentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TodoID != todoID {
+		return nil, domain.ErrNotFound
+	}
+	return attachment, nil
+}
+
+func (u *attachmentUsecase) GetByTodoID(todoID uint) ([]*domain.Attachment, error) {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return nil, err
+	}
+	return u.attachmentRepo.GetByTodoID(todoID)
+}
+
+func (u *attachmentUsecase) Open(todoID, id uint) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := u.GetByID(todoID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := u.blobs.Open(attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// Delete removes the attachment's metadata before its blob, so a failure
+// can at worst leave an unreferenced blob behind, never a dangling record.
+func (u *attachmentUsecase) Delete(todoID, id uint) error {
+	attachment, err := u.GetByID(todoID, id)
+	if err != nil {
+		return err
+	}
+	if err := u.attachmentRepo.Delete(id); err != nil {
+		return err
+	}
+	u.removeBlob(attachment.StorageKey)
+	return nil
+}
+
+func (u *attachmentUsecase) removeBlob(key string) {
+	if err := u.blobs.Delete(key); err != nil {
+		log.Printf("failed to delete blob %s: %v", key, err)
+	}
+}
+
+// newBlobKey returns a random key so that stored files never collide and
+// cannot be guessed from the attachment's metadata.
+func newBlobKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// cleanFilename strips any directory part a client may have sent along with
+// the name of an uploaded file, as well as invalid UTF-8 and control
+// characters, which the database would reject. Long names keep their end,
+// where the extension is.
+func cleanFilename(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(name, ""))
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "attachment"
+	}
+	if runes := []rune(name); len(runes) > maxAttachmentFilenameLength {
+		name = string(runes[len(runes)-maxAttachmentFilenameLength:])
+	}
+	return name
+}
+
+// cleanContentType returns the content type a client sent for an upload in
+// its canonical form, dropping the parameters if they make it too long. It
+// returns "" for a content type that is invalid or still too long, so that
+// the type is detected from the content instead.
+func cleanContentType(contentType string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	contentType = mime.FormatMediaType(mediaType, params)
+	if len(contentType) > maxAttachmentContentTypeLength {
+		contentType = mime.FormatMediaType(mediaType, nil)
+	}
+	if len(contentType) > maxAttachmentContentTypeLength {
+		return ""
+	}
+	return contentType
+}
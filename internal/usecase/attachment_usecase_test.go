@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"go-todo-api/internal/domain"
+)
+
+type stubAttachmentRepo struct {
+	domain.AttachmentRepository
+	attachment domain.Attachment
+}
+
+func (r *stubAttachmentRepo) GetByID(id uint) (*domain.Attachment, error) {
+	if id != r.attachment.ID {
+		return nil, domain.ErrNotFound
+	}
+	attachment := r.attachment
+	return &attachment, nil
+}
+
+// openBlobs counts the blobs opened.
+type openBlobs struct {
+	domain.BlobStore
+	opened int
+}
+
+func (b *openBlobs) Open(key string) (io.ReadCloser, error) {
+	b.opened++
+	return io.NopCloser(strings.NewReader("content")), nil
+}
+
+func TestOpenAttachment(t *testing.T) {
+	tests := []struct {
+		name    string
+		todoID  uint
+		todoErr error
+		wantErr error
+	}{
+		{name: "attachment of the todo", todoID: 1},
+		{name: "attachment of another todo", todoID: 2, wantErr: domain.ErrNotFound},
+		{name: "todo in the trash", todoID: 1, todoErr: domain.ErrNotFound, wantErr: domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobs := &openBlobs{}
+			u := NewAttachmentUsecase(
+				&stubAttachmentRepo{attachment: domain.Attachment{ID: 5, TodoID: 1, StorageKey: "key"}},
+				&stubTodoRepo{todo: &domain.Todo{ID: tt.todoID}, err: tt.todoErr},
+				blobs,
+				1024,
+			)
+
+			_, content, err := u.Open(tt.todoID, 5)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+				}
+				if blobs.opened != 0 {
+					t.Error("the content was opened")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			content.Close()
+		})
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	long := strings.Repeat("ä", maxAttachmentFilenameLength)
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "notes.pdf", want: "notes.pdf"},
+		{name: "../../etc/passwd", want: "passwd"},
+		{name: `C:\Users\alice\notes.pdf`, want: "notes.pdf"},
+		{name: "  ", want: "attachment"},
+		{name: "..", want: "attachment"},
+		{name: "no\x00tes\n.pdf", want: "notes.pdf"},
+		{name: "not\xffes.pdf", want: "notes.pdf"},
+		{name: "x" + long + ".pdf", want: strings.Repeat("ä", maxAttachmentFilenameLength-4) + ".pdf"},
+		{name: long, want: long},
+	}
+	for _, tt := range tests {
+		got := cleanFilename(tt.name)
+		if got != tt.want {
+			t.Errorf("cleanFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+		if !utf8.ValidString(got) || utf8.RuneCountInString(got) > maxAttachmentFilenameLength {
+			t.Errorf("cleanFilename(%q) = %q, which does not fit the column", tt.name, got)
+		}
+	}
+}
+
+func TestCleanContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        string
+	}{
+		{"application/pdf", "application/pdf"},
+		{"Text/Plain; Charset=UTF-8", "text/plain; charset=UTF-8"},
+		{"text/plain; name=" + strings.Repeat("a", 300), "text/plain"},
+		{"application/" + strings.Repeat("a", 300), ""},
+		{"not a type", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := cleanContentType(tt.contentType); got != tt.want {
+			t.Errorf("cleanContentType(%q) = %q, want %q", tt.contentType, got, tt.want)
+		}
+	}
+}
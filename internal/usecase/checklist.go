@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"go-todo-api/internal/domain"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxChecklistItems      = 100
+	maxChecklistItemLength = 500
+)
+
+func (u *todoUsecase) AddChecklistItem(todoID uint, item *domain.ChecklistItem) (*domain.Todo, error) {
+	item.Text = strings.TrimSpace(item.Text)
+	if err := validateChecklistText(item.Text); err != nil {
+		return nil, err
+	}
+
+	err := u.todoRepo.UpdateChecklist(todoID, func(checklist []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		if len(checklist) >= maxChecklistItems {
+			return nil, domain.ErrChecklistTooLong
+		}
+		item.ID = nextChecklistItemID(checklist)
+		return append(checklist, *item), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetByID(todoID)
+}
+
+func (u *todoUsecase) ToggleChecklistItem(todoID, itemID uint) (*domain.Todo, error) {
+	checked := false
+	err := u.todoRepo.UpdateChecklist(todoID, func(checklist []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		i := checklistItemIndex(checklist, itemID)
+		if i < 0 {
+			return nil, domain.ErrNotFound
+		}
+		checklist[i].Checked = !checklist[i].Checked
+		checked = checklist[i].Checked
+		return checklist, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := u.GetByID(todoID)
+	if err != nil {
+		return nil, err
+	}
+	// Auto-completion goes through Update so that recurring todos move on
+	// to their next occurrence. A blocked todo, or one whose status cannot
+	// move to done, simply stays open.
+	if checked && todo.ChecklistAutoComplete && !todo.Completed && !todo.Blocked && checklistDone(todo.Checklist) &&
+		u.workflow.CanTransition(todo.Status, u.workflow.Done) {
+		todo.Status = u.workflow.Done
+		if err := u.Update(todo); err != nil {
+			return nil, err
+		}
+	}
+	return todo, nil
+}
+
+func (u *todoUsecase) ReorderChecklist(todoID uint, order domain.ChecklistOrder) (*domain.Todo, error) {
+	err := u.todoRepo.UpdateChecklist(todoID, func(checklist []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		if len(order.ItemIDs) != len(checklist) {
+			return nil, domain.ErrInvalidChecklistOrder
+		}
+		reordered := make([]domain.ChecklistItem, 0, len(checklist))
+		seen := make(map[uint]bool, len(checklist))
+		for _, id := range order.ItemIDs {
+			i := checklistItemIndex(checklist, id)
+			if i < 0 || seen[id] {
+				return nil, domain.ErrInvalidChecklistOrder
+			}
+			seen[id] = true
+			reordered = append(reordered, checklist[i])
+		}
+		return reordered, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetByID(todoID)
+}
+
+func (u *todoUsecase) RemoveChecklistItem(todoID, itemID uint) (*domain.Todo, error) {
+	err := u.todoRepo.UpdateChecklist(todoID, func(checklist []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+		i := checklistItemIndex(checklist, itemID)
+		if i < 0 {
+			return nil, domain.ErrNotFound
+		}
+		return append(checklist[:i], checklist[i+1:]...), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetByID(todoID)
+}
+
+// normalizeChecklist validates the checklist a todo is created with and
+// numbers its items.
+func normalizeChecklist(todo *domain.Todo) error {
+	if len(todo.Checklist) > maxChecklistItems {
+		return domain.ErrChecklistTooLong
+	}
+	checklist := make([]domain.ChecklistItem, len(todo.Checklist))
+	for i, item := range todo.Checklist {
+		item.ID = uint(i + 1)
+		item.Text = strings.TrimSpace(item.Text)
+		if err := validateChecklistText(item.Text); err != nil {
+			return err
+		}
+		checklist[i] = item
+	}
+	todo.Checklist = checklist
+	return nil
+}
+
+// resetChecklist returns a copy of checklist with every item unchecked.
+func resetChecklist(checklist []domain.ChecklistItem) []domain.ChecklistItem {
+	reset := make([]domain.ChecklistItem, len(checklist))
+	for i, item := range checklist {
+		item.Checked = false
+		reset[i] = item
+	}
+	return reset
+}
+
+func validateChecklistText(text string) error {
+	if text == "" || utf8.RuneCountInString(text) > maxChecklistItemLength {
+		return domain.ErrInvalidChecklistItem
+	}
+	return nil
+}
+
+func checklistItemIndex(checklist []domain.ChecklistItem, id uint) int {
+	for i, item := range checklist {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextChecklistItemID returns an ID that no item of checklist uses. IDs are
+// never reused while the item holding the highest one exists.
+func nextChecklistItemID(checklist []domain.ChecklistItem) uint {
+	var max uint
+	for _, item := range checklist {
+		if item.ID > max {
+			max = item.ID
+		}
+	}
+	return max + 1
+}
+
+func checklistDone(checklist []domain.ChecklistItem) bool {
+	for _, item := range checklist {
+		if !item.Checked {
+			return false
+		}
+	}
+	return len(checklist) > 0
+}
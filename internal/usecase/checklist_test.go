@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+// checklistRepo holds a single todo and records its updates.
+type checklistRepo struct {
+	domain.TodoRepository
+	todo    domain.Todo
+	updates int
+}
+
+func (r *checklistRepo) GetByID(id uint) (*domain.Todo, error) {
+	todo := r.todo
+	todo.Checklist = append([]domain.ChecklistItem(nil), r.todo.Checklist...)
+	return &todo, nil
+}
+
+func (r *checklistRepo) GetLinks(id uint) ([]domain.TodoLink, error) {
+	return nil, nil
+}
+
+func (r *checklistRepo) UpdateChecklist(id uint, update func([]domain.ChecklistItem) ([]domain.ChecklistItem, error)) error {
+	checklist, err := update(append([]domain.ChecklistItem(nil), r.todo.Checklist...))
+	if err != nil {
+		return err
+	}
+	r.todo.Checklist = checklist
+	return nil
+}
+
+func (r *checklistRepo) Update(todo *domain.Todo) error {
+	r.todo = *todo
+	r.updates++
+	return nil
+}
+
+func checklistIDs(checklist []domain.ChecklistItem) []uint {
+	ids := make([]uint, len(checklist))
+	for i, item := range checklist {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestChecklistItems(t *testing.T) {
+	repo := &checklistRepo{todo: domain.Todo{ID: 1, Checklist: []domain.ChecklistItem{
+		{ID: 1, Text: "draft"},
+		{ID: 2, Text: "review"},
+	}}}
+	u := &todoUsecase{todoRepo: repo, workflow: domain.DefaultWorkflow()}
+
+	todo, err := u.AddChecklistItem(1, &domain.ChecklistItem{Text: "  publish "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := todo.Checklist[2]; got.ID != 3 || got.Text != "publish" {
+		t.Errorf("AddChecklistItem() added %+v, want item 3 with text %q", got, "publish")
+	}
+
+	// Removing an item in the middle must not free its ID for the next one.
+	if _, err := u.RemoveChecklistItem(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if todo, err = u.AddChecklistItem(1, &domain.ChecklistItem{Text: "announce"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := checklistIDs(todo.Checklist), []uint{1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("checklist IDs = %v, want %v", got, want)
+	}
+
+	if todo, err = u.ReorderChecklist(1, domain.ChecklistOrder{ItemIDs: []uint{4, 1, 3}}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := checklistIDs(todo.Checklist), []uint{4, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reordered checklist IDs = %v, want %v", got, want)
+	}
+
+	for _, itemIDs := range [][]uint{{4, 1}, {4, 1, 3, 5}, {4, 1, 1}, {4, 1, 9}} {
+		if _, err := u.ReorderChecklist(1, domain.ChecklistOrder{ItemIDs: itemIDs}); !errors.Is(err, domain.ErrInvalidChecklistOrder) {
+			t.Errorf("ReorderChecklist(%v) error = %v, want %v", itemIDs, err, domain.ErrInvalidChecklistOrder)
+		}
+	}
+	for _, text := range []string{"", "   ", strings.Repeat("ä", maxChecklistItemLength+1)} {
+		if _, err := u.AddChecklistItem(1, &domain.ChecklistItem{Text: text}); !errors.Is(err, domain.ErrInvalidChecklistItem) {
+			t.Errorf("AddChecklistItem(%q) error = %v, want %v", text, err, domain.ErrInvalidChecklistItem)
+		}
+	}
+	if _, err := u.ToggleChecklistItem(1, 2); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ToggleChecklistItem() of a removed item error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if _, err := u.RemoveChecklistItem(1, 2); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("RemoveChecklistItem() of a removed item error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if got, want := checklistIDs(repo.todo.Checklist), []uint{4, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stored checklist IDs = %v, want %v", got, want)
+	}
+}
+
+func TestChecklistTooLong(t *testing.T) {
+	checklist := make([]domain.ChecklistItem, maxChecklistItems)
+	for i := range checklist {
+		checklist[i] = domain.ChecklistItem{ID: uint(i + 1), Text: "step"}
+	}
+	u := &todoUsecase{todoRepo: &checklistRepo{todo: domain.Todo{ID: 1, Checklist: checklist}}}
+
+	if _, err := u.AddChecklistItem(1, &domain.ChecklistItem{Text: "one more"}); !errors.Is(err, domain.ErrChecklistTooLong) {
+		t.Errorf("AddChecklistItem() error = %v, want %v", err, domain.ErrChecklistTooLong)
+	}
+	todo := &domain.Todo{Checklist: append(checklist, domain.ChecklistItem{Text: "one more"})}
+	if err := normalizeChecklist(todo); !errors.Is(err, domain.ErrChecklistTooLong) {
+		t.Errorf("normalizeChecklist() error = %v, want %v", err, domain.ErrChecklistTooLong)
+	}
+}
+
+func TestToggleChecklistItemAutoComplete(t *testing.T) {
+	tests := []struct {
+		name          string
+		todo          domain.Todo
+		itemID        uint
+		wantCompleted bool
+	}{
+		{
+			name:          "last open item",
+			todo:          domain.Todo{ChecklistAutoComplete: true, Checklist: []domain.ChecklistItem{{ID: 1, Checked: true}, {ID: 2}}},
+			itemID:        2,
+			wantCompleted: true,
+		},
+		{
+			name:   "other items open",
+			todo:   domain.Todo{ChecklistAutoComplete: true, Checklist: []domain.ChecklistItem{{ID: 1}, {ID: 2}}},
+			itemID: 2,
+		},
+		{
+			name:   "item unchecked",
+			todo:   domain.Todo{ChecklistAutoComplete: true, Checklist: []domain.ChecklistItem{{ID: 1, Checked: true}, {ID: 2, Checked: true}}},
+			itemID: 2,
+		},
+		{
+			name:   "auto-completion off",
+			todo:   domain.Todo{Checklist: []domain.ChecklistItem{{ID: 1, Checked: true}, {ID: 2}}},
+			itemID: 2,
+		},
+		{
+			name:   "blocked",
+			todo:   domain.Todo{ChecklistAutoComplete: true, Blocked: true, Checklist: []domain.ChecklistItem{{ID: 1, Checked: true}, {ID: 2}}},
+			itemID: 2,
+		},
+		{
+			// The default workflow does not allow a blocked todo to move to
+			// done.
+			name:   "status cannot move to done",
+			todo:   domain.Todo{ChecklistAutoComplete: true, Status: domain.StatusBlocked, Checklist: []domain.ChecklistItem{{ID: 1, Checked: true}, {ID: 2}}},
+			itemID: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.todo.ID = 1
+			if tt.todo.Status == "" {
+				tt.todo.Status = domain.StatusOpen
+			}
+			tt.todo.Priority = domain.PriorityNone
+			repo := &checklistRepo{todo: tt.todo}
+			u := &todoUsecase{todoRepo: repo, workflow: domain.DefaultWorkflow()}
+
+			todo, err := u.ToggleChecklistItem(1, tt.itemID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if todo.Completed != tt.wantCompleted || repo.todo.Completed != tt.wantCompleted {
+				t.Errorf("ToggleChecklistItem() completed = %v, stored %v, want %v", todo.Completed, repo.todo.Completed, tt.wantCompleted)
+			}
+			if tt.wantCompleted && repo.todo.Status != domain.StatusDone {
+				t.Errorf("stored status = %q, want %q", repo.todo.Status, domain.StatusDone)
+			}
+			if !tt.wantCompleted && repo.updates != 0 {
+				t.Errorf("todo was updated %d times, want it unchanged", repo.updates)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"go-todo-api/internal/domain"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxCommentAuthorLength = 100
+
+type commentUsecase struct {
+	commentRepo domain.CommentRepository
+	todoRepo    domain.TodoRepository
+}
+
+func NewCommentUsecase(repo domain.CommentRepository, todoRepo domain.TodoRepository) domain.CommentUsecase {
+	return &commentUsecase{
+		commentRepo: repo,
+		todoRepo:    todoRepo,
+	}
+}
+
+func (u *commentUsecase) Create(comment *domain.Comment) error {
+	comment.Author = strings.TrimSpace(comment.Author)
+	if comment.Author == "" || utf8.RuneCountInString(comment.Author) > maxCommentAuthorLength {
+		return domain.ErrInvalidCommentAuthor
+	}
+	if strings.TrimSpace(comment.Body) == "" {
+		return domain.ErrEmptyComment
+	}
+	if _, err := u.todoRepo.GetByID(comment.TodoID); err != nil {
+		return err
+	}
+	return u.commentRepo.Create(comment)
+}
+
+// GetByID returns a comment of a todo. The comments of a todo in the trash
+// are not found, like the todo itself, so they cannot be changed either.
+func (u *commentUsecase) GetByID(todoID, id uint) (*domain.Comment, error) {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return nil, err
+	}
+	comment, err := u.commentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TodoID != todoID {
+		return nil, domain.ErrNotFound
+	}
+	return comment, nil
+}
+
+func (u *commentUsecase) GetByTodoID(todoID uint) ([]*domain.Comment, error) {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return nil, err
+	}
+	return u.commentRepo.GetByTodoID(todoID)
+}
+
+func (u *commentUsecase) Update(comment *domain.Comment) error {
+	if strings.TrimSpace(comment.Body) == "" {
+		return domain.ErrEmptyComment
+	}
+	if _, err := u.GetByID(comment.TodoID, comment.ID); err != nil {
+		return err
+	}
+	return u.commentRepo.Update(comment)
+}
+
+func (u *commentUsecase) Delete(todoID, id uint) error {
+	if _, err := u.GetByID(todoID, id); err != nil {
+		return err
+	}
+	return u.commentRepo.Delete(id)
+}
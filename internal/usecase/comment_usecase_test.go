@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+// commentRepo holds a single comment and records the changes made to it.
+type commentRepo struct {
+	domain.CommentRepository
+	comment domain.Comment
+	changes int
+}
+
+func (r *commentRepo) GetByID(id uint) (*domain.Comment, error) {
+	if id != r.comment.ID {
+		return nil, domain.ErrNotFound
+	}
+	comment := r.comment
+	return &comment, nil
+}
+
+func (r *commentRepo) Update(comment *domain.Comment) error {
+	r.changes++
+	return nil
+}
+
+func (r *commentRepo) Delete(id uint) error {
+	r.changes++
+	return nil
+}
+
+func TestCommentOfTrashedTodo(t *testing.T) {
+	actions := map[string]func(u domain.CommentUsecase, todoID uint) error{
+		"get": func(u domain.CommentUsecase, todoID uint) error {
+			_, err := u.GetByID(todoID, 5)
+			return err
+		},
+		"update": func(u domain.CommentUsecase, todoID uint) error {
+			return u.Update(&domain.Comment{ID: 5, TodoID: todoID, Body: "edited"})
+		},
+		"delete": func(u domain.CommentUsecase, todoID uint) error {
+			return u.Delete(todoID, 5)
+		},
+	}
+	tests := []struct {
+		name    string
+		todoID  uint
+		trashed bool
+		wantErr error
+	}{
+		{name: "comment of the todo", todoID: 1},
+		{name: "comment of another todo", todoID: 2, wantErr: domain.ErrNotFound},
+		{name: "todo in the trash", todoID: 1, trashed: true, wantErr: domain.ErrNotFound},
+	}
+	for action, do := range actions {
+		for _, tt := range tests {
+			t.Run(action+" "+tt.name, func(t *testing.T) {
+				comments := &commentRepo{comment: domain.Comment{ID: 5, TodoID: 1, Author: "alice", Body: "note"}}
+				u := NewCommentUsecase(comments, &trashTodoRepo{trashed: map[uint]bool{1: tt.trashed}})
+
+				err := do(u, tt.todoID)
+				if !errors.Is(err, tt.wantErr) || err != nil && tt.wantErr == nil {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil && comments.changes != 0 {
+					t.Error("the comment was changed")
+				}
+			})
+		}
+	}
+}
+
+func (r *commentRepo) Create(comment *domain.Comment) error {
+	r.changes++
+	return nil
+}
+
+func TestCreateCommentAuthor(t *testing.T) {
+	tests := []struct {
+		author  string
+		wantErr bool
+	}{
+		{author: "alice"},
+		{author: strings.Repeat("é", maxCommentAuthorLength)},
+		{author: strings.Repeat("é", maxCommentAuthorLength+1), wantErr: true},
+		{author: " ", wantErr: true},
+	}
+	for _, tt := range tests {
+		u := NewCommentUsecase(&commentRepo{}, &trashTodoRepo{})
+		err := u.Create(&domain.Comment{TodoID: 1, Author: tt.author, Body: "note"})
+		if tt.wantErr != errors.Is(err, domain.ErrInvalidCommentAuthor) || err != nil && !tt.wantErr {
+			t.Errorf("Create() with author %q error = %v, want an error: %v", tt.author, err, tt.wantErr)
+		}
+	}
+}
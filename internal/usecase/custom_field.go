@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"fmt"
+	"go-todo-api/internal/domain"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxCustomFields          = 50
+	maxCustomFieldNameLength = 100
+	maxCustomFieldOptions    = 100
+	maxCustomFieldTextLength = 1000
+)
+
+// customFieldKeyPattern keeps keys usable as query parameter names and JSON
+// keys without escaping.
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// validateCustomFieldDefinitions checks the custom fields of a project and
+// fills in defaults.
+func validateCustomFieldDefinitions(project *domain.Project) error {
+	if project.CustomFields == nil {
+		project.CustomFields = []domain.CustomFieldDefinition{}
+	}
+	if len(project.CustomFields) > maxCustomFields {
+		return fmt.Errorf("%w: a project can have at most %d custom fields", domain.ErrInvalidCustomField, maxCustomFields)
+	}
+
+	seen := make(map[string]bool, len(project.CustomFields))
+	for i := range project.CustomFields {
+		field := &project.CustomFields[i]
+		if !customFieldKeyPattern.MatchString(field.Key) {
+			return fmt.Errorf("%w: key %q must start with a lowercase letter and contain only lowercase letters, digits and underscores", domain.ErrInvalidCustomField, field.Key)
+		}
+		if seen[field.Key] {
+			return fmt.Errorf("%w: key %q is defined twice", domain.ErrInvalidCustomField, field.Key)
+		}
+		seen[field.Key] = true
+
+		field.Name = strings.TrimSpace(field.Name)
+		if field.Name == "" {
+			field.Name = field.Key
+		}
+		if utf8.RuneCountInString(field.Name) > maxCustomFieldNameLength {
+			return fmt.Errorf("%w: name of %q is too long", domain.ErrInvalidCustomField, field.Key)
+		}
+
+		switch field.Type {
+		case domain.CustomFieldEnum:
+			if err := validateCustomFieldOptions(field); err != nil {
+				return err
+			}
+		case domain.CustomFieldText, domain.CustomFieldNumber, domain.CustomFieldDate, domain.CustomFieldBoolean:
+			if len(field.Options) > 0 {
+				return fmt.Errorf("%w: only enum fields have options, %q is a %s field", domain.ErrInvalidCustomField, field.Key, field.Type)
+			}
+		default:
+			return fmt.Errorf("%w: type of %q must be one of text, number, date, enum, boolean", domain.ErrInvalidCustomField, field.Key)
+		}
+	}
+	return nil
+}
+
+func validateCustomFieldOptions(field *domain.CustomFieldDefinition) error {
+	if len(field.Options) == 0 || len(field.Options) > maxCustomFieldOptions {
+		return fmt.Errorf("%w: enum field %q needs between 1 and %d options", domain.ErrInvalidCustomField, field.Key, maxCustomFieldOptions)
+	}
+	seen := make(map[string]bool, len(field.Options))
+	for _, option := range field.Options {
+		if option == "" || seen[option] {
+			return fmt.Errorf("%w: options of %q must be unique and not empty", domain.ErrInvalidCustomField, field.Key)
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+// checkCustomFieldTypes rejects changing the type of an existing custom
+// field, which would leave the stored values of todos invalid.
+func checkCustomFieldTypes(existing, updated []domain.CustomFieldDefinition) error {
+	types := make(map[string]domain.CustomFieldType, len(existing))
+	for _, field := range existing {
+		types[field.Key] = field.Type
+	}
+	for _, field := range updated {
+		if old, ok := types[field.Key]; ok && old != field.Type {
+			return fmt.Errorf("%w: the type of %q cannot be changed from %s to %s; remove the field and add a new one instead",
+				domain.ErrInvalidCustomField, field.Key, old, field.Type)
+		}
+	}
+	return nil
+}
+
+// validateCustomFieldValues checks the custom field values of a todo against
+// the definitions of its project, which may be nil for a todo without a
+// project. It normalizes the values and drops null ones.
+//
+// existing is the stored todo when todo replaces it and nil for a new todo.
+// A field made required after a todo was created is only enforced once the
+// todo has a value for it, or when the todo moves to the project, so that
+// other changes to the todo are not rejected.
+func validateCustomFieldValues(todo, existing *domain.Todo, project *domain.Project) error {
+	values := make(map[string]any, len(todo.CustomFields))
+	for key, value := range todo.CustomFields {
+		if value == nil {
+			continue
+		}
+		if project == nil {
+			return domain.ErrCustomFieldsNeedProject
+		}
+		field, ok := project.CustomFieldDefinitionFor(key)
+		if !ok {
+			return fmt.Errorf("%w: project has no custom field %q", domain.ErrInvalidCustomValue, key)
+		}
+		normalized, err := normalizeCustomFieldValue(field, value)
+		if err != nil {
+			return err
+		}
+		values[key] = normalized
+	}
+
+	if project != nil {
+		for _, field := range project.CustomFields {
+			if _, ok := values[field.Key]; !field.Required || ok {
+				continue
+			}
+			if existing != nil && sameProject(existing.ProjectID, todo.ProjectID) {
+				if _, had := existing.CustomFields[field.Key]; !had {
+					continue
+				}
+			}
+			return fmt.Errorf("%w: %q is required", domain.ErrInvalidCustomValue, field.Key)
+		}
+	}
+	todo.CustomFields = values
+	return nil
+}
+
+func sameProject(a, b *uint) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+// normalizeCustomFieldValue checks a value decoded from JSON against the
+// type of its field.
+func normalizeCustomFieldValue(field domain.CustomFieldDefinition, value any) (any, error) {
+	invalid := fmt.Errorf("%w: %q must be a %s", domain.ErrInvalidCustomValue, field.Key, customFieldTypeDescription(field))
+	switch field.Type {
+	case domain.CustomFieldText:
+		text, ok := value.(string)
+		if !ok || utf8.RuneCountInString(text) > maxCustomFieldTextLength {
+			return nil, invalid
+		}
+		return text, nil
+	case domain.CustomFieldNumber:
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, invalid
+		}
+		return number, nil
+	case domain.CustomFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		date, err := time.Parse(time.DateOnly, text)
+		if err != nil {
+			return nil, invalid
+		}
+		return date.Format(time.DateOnly), nil
+	case domain.CustomFieldEnum:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		for _, option := range field.Options {
+			if option == text {
+				return text, nil
+			}
+		}
+		return nil, invalid
+	case domain.CustomFieldBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, invalid
+		}
+		return b, nil
+	default:
+		return nil, invalid
+	}
+}
+
+// parseCustomFieldValue turns the text of a query parameter into a value of
+// the type of field.
+func parseCustomFieldValue(field domain.CustomFieldDefinition, raw string) (any, error) {
+	var value any = raw
+	switch field.Type {
+	case domain.CustomFieldNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q must be a number", domain.ErrInvalidFilter, field.Key)
+		}
+		value = number
+	case domain.CustomFieldBoolean:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q must be true or false", domain.ErrInvalidFilter, field.Key)
+		}
+		value = b
+	}
+	normalized, err := normalizeCustomFieldValue(field, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q must be a %s", domain.ErrInvalidFilter, field.Key, customFieldTypeDescription(field))
+	}
+	return normalized, nil
+}
+
+func customFieldTypeDescription(field domain.CustomFieldDefinition) string {
+	switch field.Type {
+	case domain.CustomFieldDate:
+		return "date like 2006-01-02"
+	case domain.CustomFieldEnum:
+		return "one of " + strings.Join(field.Options, ", ")
+	case domain.CustomFieldText:
+		return fmt.Sprintf("text of at most %d characters", maxCustomFieldTextLength)
+	default:
+		return string(field.Type)
+	}
+}
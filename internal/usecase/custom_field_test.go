@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+func TestValidateCustomFieldValuesRequired(t *testing.T) {
+	projectID, otherID := uint(1), uint(2)
+	project := &domain.Project{ID: projectID, CustomFields: []domain.CustomFieldDefinition{
+		{Key: "stage", Type: domain.CustomFieldEnum, Options: []string{"alpha", "beta"}, Required: true},
+		{Key: "points", Type: domain.CustomFieldNumber},
+	}}
+
+	tests := []struct {
+		name     string
+		values   map[string]any
+		existing *domain.Todo
+		wantErr  bool
+	}{
+		{
+			name:    "new todo without the value",
+			values:  map[string]any{"points": 3.0},
+			wantErr: true,
+		},
+		{
+			name:   "new todo with the value",
+			values: map[string]any{"stage": "beta"},
+		},
+		{
+			name:     "todo created before the field was required",
+			values:   map[string]any{"points": 3.0},
+			existing: &domain.Todo{ProjectID: &projectID, CustomFields: map[string]any{"points": 2.0}},
+		},
+		{
+			name:     "value removed",
+			values:   map[string]any{"stage": nil},
+			existing: &domain.Todo{ProjectID: &projectID, CustomFields: map[string]any{"stage": "alpha"}},
+			wantErr:  true,
+		},
+		{
+			name:     "value changed",
+			values:   map[string]any{"stage": "beta"},
+			existing: &domain.Todo{ProjectID: &projectID, CustomFields: map[string]any{"stage": "alpha"}},
+		},
+		{
+			name:     "moved from another project",
+			values:   map[string]any{"points": 3.0},
+			existing: &domain.Todo{ProjectID: &otherID},
+			wantErr:  true,
+		},
+		{
+			name:     "moved from no project",
+			values:   map[string]any{},
+			existing: &domain.Todo{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &domain.Todo{ProjectID: &projectID, CustomFields: tt.values}
+			err := validateCustomFieldValues(todo, tt.existing, project)
+			if tt.wantErr != (err != nil) || err != nil && !errors.Is(err, domain.ErrInvalidCustomValue) {
+				t.Errorf("validateCustomFieldValues() error = %v, want an error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import "go-todo-api/internal/domain"
+
+// RenderDescriptions renders the descriptions of todos to HTML. References
+// to todos that do not exist or are in the trash stay plain text; they are
+// looked up in one query for all todos.
+func (u *todoUsecase) RenderDescriptions(todos []*domain.Todo) error {
+	var refs []uint
+	for _, todo := range todos {
+		refs = append(refs, u.renderer.References(todo.Description)...)
+	}
+	existing, err := u.todoRepo.ExistingIDs(refs)
+	if err != nil {
+		return err
+	}
+	exists := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		exists[id] = true
+	}
+
+	for _, todo := range todos {
+		html, err := u.renderer.Render(todo.Description, func(id uint) bool { return exists[id] })
+		if err != nil {
+			return err
+		}
+		todo.DescriptionHTML = html
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"errors"
+
+	"go-todo-api/internal/domain"
+)
+
+func (u *todoUsecase) GetLinks(id uint) ([]domain.TodoLink, error) {
+	if _, err := u.todoRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return u.todoRepo.GetLinks(id)
+}
+
+// AddLink links two todos. Marking a todo as a duplicate, from either side,
+// closes the duplicate with the workflow's duplicate status; it cannot
+// already be the duplicate of another todo, and two todos cannot be
+// duplicates of each other.
+func (u *todoUsecase) AddLink(id uint, link domain.TodoLink) (*domain.Todo, error) {
+	if _, ok := link.Type.Inverse(); !ok {
+		return nil, domain.ErrInvalidLinkType
+	}
+	if link.TodoID == id {
+		return nil, domain.ErrSelfLink
+	}
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := u.todoRepo.GetByID(link.TodoID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrUnknownLinkedTodo
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicate *domain.Todo
+	var canonicalID uint
+	switch link.Type {
+	case domain.LinkDuplicateOf:
+		duplicate, canonicalID = todo, linked.ID
+	case domain.LinkDuplicatedBy:
+		duplicate, canonicalID = linked, todo.ID
+	}
+	// The duplicate is closed together with adding the link, so the status
+	// change is checked like an update before anything is written. Closing
+	// it this way does not continue a recurring series.
+	var closed *domain.Todo
+	if duplicate != nil {
+		canonicalRoot, err := u.todoRepo.CanonicalID(canonicalID)
+		if err != nil {
+			return nil, err
+		}
+		if canonicalRoot == duplicate.ID {
+			return nil, domain.ErrDuplicateCycle
+		}
+		if !duplicate.Completed {
+			duplicate.Status = u.workflow.DuplicateStatus()
+			if _, err := u.prepareUpdate(duplicate); err != nil {
+				return nil, err
+			}
+			closed = duplicate
+		}
+	}
+
+	link.Title = ""
+	link.Status = ""
+	if err := u.todoRepo.AddLink(id, link, closed); err != nil {
+		return nil, err
+	}
+	return u.GetByID(id)
+}
+
+func (u *todoUsecase) RemoveLink(id, linkedID uint) error {
+	if _, err := u.todoRepo.GetByID(id); err != nil {
+		return err
+	}
+	return u.todoRepo.RemoveLink(id, linkedID)
+}
+
+// loadLinks attaches the links of a todo and, for a duplicate, the todo it
+// ultimately duplicates.
+func (u *todoUsecase) loadLinks(todo *domain.Todo) error {
+	links, err := u.todoRepo.GetLinks(todo.ID)
+	if err != nil {
+		return err
+	}
+	todo.Links = links
+	todo.CanonicalID = nil
+	if isDuplicate(links) {
+		canonicalID, err := u.todoRepo.CanonicalID(todo.ID)
+		if err != nil {
+			return err
+		}
+		todo.CanonicalID = &canonicalID
+	}
+	return nil
+}
+
+func isDuplicate(links []domain.TodoLink) bool {
+	for _, link := range links {
+		if link.Type == domain.LinkDuplicateOf {
+			return true
+		}
+	}
+	return false
+}
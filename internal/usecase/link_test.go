@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+// linkRepo holds todos and the todos they duplicate, and records the links
+// added.
+type linkRepo struct {
+	domain.TodoRepository
+	todos     map[uint]*domain.Todo
+	canonical map[uint]uint
+	added     []domain.TodoLink
+	closed    *domain.Todo
+}
+
+func (r *linkRepo) GetByID(id uint) (*domain.Todo, error) {
+	todo, ok := r.todos[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	stored := *todo
+	return &stored, nil
+}
+
+func (r *linkRepo) GetLinks(id uint) ([]domain.TodoLink, error) {
+	return nil, nil
+}
+
+func (r *linkRepo) CanonicalID(id uint) (uint, error) {
+	for {
+		next, ok := r.canonical[id]
+		if !ok {
+			return id, nil
+		}
+		id = next
+	}
+}
+
+func (r *linkRepo) AddLink(id uint, link domain.TodoLink, closed *domain.Todo) error {
+	r.added = append(r.added, link)
+	r.closed = closed
+	return nil
+}
+
+func TestAddLink(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         uint
+		link       domain.TodoLink
+		wantErr    error
+		wantClosed uint
+	}{
+		{name: "relates to", id: 1, link: domain.TodoLink{Type: domain.LinkRelatesTo, TodoID: 2}},
+		{name: "follow-up", id: 1, link: domain.TodoLink{Type: domain.LinkFollowUpOf, TodoID: 2}},
+		{name: "duplicate of", id: 1, link: domain.TodoLink{Type: domain.LinkDuplicateOf, TodoID: 2}, wantClosed: 1},
+		{name: "duplicated by", id: 1, link: domain.TodoLink{Type: domain.LinkDuplicatedBy, TodoID: 2}, wantClosed: 2},
+		{name: "duplicate already closed", id: 4, link: domain.TodoLink{Type: domain.LinkDuplicateOf, TodoID: 1}},
+		{name: "duplicate of its own duplicate", id: 2, link: domain.TodoLink{Type: domain.LinkDuplicateOf, TodoID: 3}, wantErr: domain.ErrDuplicateCycle},
+		{name: "linked to itself", id: 1, link: domain.TodoLink{Type: domain.LinkRelatesTo, TodoID: 1}, wantErr: domain.ErrSelfLink},
+		{name: "unknown type", id: 1, link: domain.TodoLink{Type: "blocks", TodoID: 2}, wantErr: domain.ErrInvalidLinkType},
+		{name: "unknown linked todo", id: 1, link: domain.TodoLink{Type: domain.LinkRelatesTo, TodoID: 9}, wantErr: domain.ErrUnknownLinkedTodo},
+		{name: "unknown todo", id: 9, link: domain.TodoLink{Type: domain.LinkRelatesTo, TodoID: 1}, wantErr: domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 3 is already a duplicate of 2, and 4 is done.
+			repo := &linkRepo{
+				todos: map[uint]*domain.Todo{
+					1: {ID: 1, Status: domain.StatusOpen},
+					2: {ID: 2, Status: domain.StatusInProgress},
+					3: {ID: 3, Status: domain.StatusCancelled, Completed: true},
+					4: {ID: 4, Status: domain.StatusDone, Completed: true},
+				},
+				canonical: map[uint]uint{3: 2},
+			}
+			u := &todoUsecase{todoRepo: repo, workflow: domain.DefaultWorkflow()}
+
+			_, err := u.AddLink(tt.id, tt.link)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("AddLink() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.added) != 0 {
+					t.Errorf("links added = %v, want none", repo.added)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(repo.added) != 1 || repo.added[0].Type != tt.link.Type || repo.added[0].TodoID != tt.link.TodoID {
+				t.Fatalf("links added = %v, want %v", repo.added, tt.link)
+			}
+			switch {
+			case tt.wantClosed == 0 && repo.closed != nil:
+				t.Errorf("AddLink() closed todo %d, want none closed", repo.closed.ID)
+			case tt.wantClosed != 0 && repo.closed == nil:
+				t.Errorf("AddLink() closed no todo, want %d closed", tt.wantClosed)
+			case tt.wantClosed != 0 && (repo.closed.ID != tt.wantClosed || repo.closed.Status != domain.StatusCancelled || !repo.closed.Completed):
+				t.Errorf("AddLink() closed todo %d as %q, completed %v, want %d as %q",
+					repo.closed.ID, repo.closed.Status, repo.closed.Completed, tt.wantClosed, domain.StatusCancelled)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"errors"
+	"go-todo-api/internal/domain"
+)
+
+func (u *todoUsecase) Move(id uint, move domain.TodoMove) (*domain.Todo, error) {
+	if (move.Before == nil) == (move.After == nil) {
+		return nil, domain.ErrInvalidMove
+	}
+	after := move.After != nil
+	targetID := move.Before
+	if after {
+		targetID = move.After
+	}
+	if *targetID == id {
+		return nil, domain.ErrInvalidMove
+	}
+	if _, err := u.todoRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	// Once the neighbouring positions leave no room or the new position
+	// gets too long, all positions are spread out again and the move is
+	// retried.
+	for attempt := 0; ; attempt++ {
+		target, neighbour, err := u.todoRepo.AdjacentPositions(*targetID, id, after)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnknownMoveTarget
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		lower, upper := neighbour, target
+		if after {
+			lower, upper = target, neighbour
+		}
+		position, ok := domain.PositionBetween(lower, upper)
+		if ok && (len(position) <= domain.MaxPositionLength || attempt > 0) {
+			if err := u.todoRepo.SetPosition(id, position); err != nil {
+				return nil, err
+			}
+			return u.GetByID(id)
+		}
+		if attempt > 0 {
+			return nil, errors.New("no position left between the neighbouring todos after rebalancing")
+		}
+		if err := u.todoRepo.RebalancePositions(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// lastPosition returns a position behind every existing todo, rebalancing
+// first if that position would get too long.
+func (u *todoUsecase) lastPosition() (string, error) {
+	last, err := u.todoRepo.LastPosition()
+	if err != nil {
+		return "", err
+	}
+	position, _ := domain.PositionBetween(last, "")
+	if len(position) <= domain.MaxPositionLength {
+		return position, nil
+	}
+
+	if err := u.todoRepo.RebalancePositions(); err != nil {
+		return "", err
+	}
+	if last, err = u.todoRepo.LastPosition(); err != nil {
+		return "", err
+	}
+	position, _ = domain.PositionBetween(last, "")
+	return position, nil
+}
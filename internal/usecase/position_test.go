@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+// positionRepo keeps the manual order of todos in memory, sorted by
+// position and then ID like the database.
+type positionRepo struct {
+	domain.TodoRepository
+	positions  map[uint]string
+	rebalances int
+}
+
+func (r *positionRepo) order() []uint {
+	ids := make([]uint, 0, len(r.positions))
+	for id := range r.positions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if r.positions[ids[i]] != r.positions[ids[j]] {
+			return r.positions[ids[i]] < r.positions[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+func (r *positionRepo) GetByID(id uint) (*domain.Todo, error) {
+	position, ok := r.positions[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.Todo{ID: id, Position: position}, nil
+}
+
+func (r *positionRepo) GetLinks(id uint) ([]domain.TodoLink, error) {
+	return nil, nil
+}
+
+func (r *positionRepo) LastPosition() (string, error) {
+	order := r.order()
+	if len(order) == 0 {
+		return "", nil
+	}
+	return r.positions[order[len(order)-1]], nil
+}
+
+func (r *positionRepo) AdjacentPositions(targetID, excludeID uint, after bool) (string, string, error) {
+	var order []uint
+	for _, id := range r.order() {
+		if id != excludeID {
+			order = append(order, id)
+		}
+	}
+	for i, id := range order {
+		if id != targetID {
+			continue
+		}
+		neighbour := ""
+		if after && i+1 < len(order) {
+			neighbour = r.positions[order[i+1]]
+		}
+		if !after && i > 0 {
+			neighbour = r.positions[order[i-1]]
+		}
+		return r.positions[id], neighbour, nil
+	}
+	return "", "", domain.ErrNotFound
+}
+
+func (r *positionRepo) SetPosition(id uint, position string) error {
+	r.positions[id] = position
+	return nil
+}
+
+func (r *positionRepo) RebalancePositions() error {
+	order := r.order()
+	for i, position := range domain.SpreadPositions(len(order)) {
+		r.positions[order[i]] = position
+	}
+	r.rebalances++
+	return nil
+}
+
+func TestMoveRebalancesLongPositions(t *testing.T) {
+	tests := []struct {
+		name string
+		move func(first, last uint) (uint, domain.TodoMove)
+	}{
+		{
+			name: "to the front",
+			move: func(first, last uint) (uint, domain.TodoMove) { return last, domain.TodoMove{Before: &first} },
+		},
+		{
+			name: "to the end",
+			move: func(first, last uint) (uint, domain.TodoMove) { return first, domain.TodoMove{After: &last} },
+		},
+		{
+			name: "behind the first",
+			move: func(first, last uint) (uint, domain.TodoMove) { return last, domain.TodoMove{After: &first} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &positionRepo{positions: make(map[uint]string)}
+			for i, position := range domain.SpreadPositions(5) {
+				repo.positions[uint(i+1)] = position
+			}
+			u := &todoUsecase{todoRepo: repo, workflow: domain.DefaultWorkflow()}
+
+			for i := 0; i < 2000 && repo.rebalances == 0; i++ {
+				order := repo.order()
+				id, move := tt.move(order[0], order[len(order)-1])
+				before := append([]uint(nil), order...)
+
+				if _, err := u.Move(id, move); err != nil {
+					t.Fatalf("move %d: %v", i, err)
+				}
+				for _, position := range repo.positions {
+					if len(position) > domain.MaxPositionLength {
+						t.Fatalf("move %d: position %q is longer than %d", i, position, domain.MaxPositionLength)
+					}
+				}
+				if got, want := repo.order(), expectedOrder(before, id, move); !slices.Equal(got, want) {
+					t.Fatalf("move %d: order = %v, want %v", i, got, want)
+				}
+			}
+			if repo.rebalances == 0 {
+				t.Fatal("positions were never rebalanced")
+			}
+		})
+	}
+}
+
+func TestLastPositionRebalances(t *testing.T) {
+	long := ""
+	for len(long) < domain.MaxPositionLength {
+		long += "z"
+	}
+	repo := &positionRepo{positions: map[uint]string{1: "F", 2: long}}
+	u := &todoUsecase{todoRepo: repo}
+
+	position, err := u.lastPosition()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.rebalances != 1 {
+		t.Errorf("rebalanced %d times, want 1", repo.rebalances)
+	}
+	if last := repo.positions[2]; position <= last || len(position) > domain.MaxPositionLength {
+		t.Errorf("lastPosition() = %q, want a short position after %q", position, last)
+	}
+}
+
+// expectedOrder applies a move to an order of IDs.
+func expectedOrder(order []uint, id uint, move domain.TodoMove) []uint {
+	var rest []uint
+	for _, other := range order {
+		if other != id {
+			rest = append(rest, other)
+		}
+	}
+	target := move.Before
+	if move.After != nil {
+		target = move.After
+	}
+	var result []uint
+	for _, other := range rest {
+		if other == *target && move.Before != nil {
+			result = append(result, id)
+		}
+		result = append(result, other)
+		if other == *target && move.After != nil {
+			result = append(result, id)
+		}
+	}
+	return result
+}
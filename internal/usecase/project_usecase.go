@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"go-todo-api/internal/domain"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxProjectNameLength = 100
+
+type projectUsecase struct {
+	projectRepo domain.ProjectRepository
+}
+
+func NewProjectUsecase(repo domain.ProjectRepository) domain.ProjectUsecase {
+	return &projectUsecase{
+		projectRepo: repo,
+	}
+}
+
+func (u *projectUsecase) Create(project *domain.Project) error {
+	if err := validateProject(project); err != nil {
+		return err
+	}
+	return u.projectRepo.Create(project)
+}
+
+func (u *projectUsecase) GetByID(id uint) (*domain.Project, error) {
+	return u.projectRepo.GetByID(id)
+}
+
+func (u *projectUsecase) GetAll() ([]*domain.Project, error) {
+	return u.projectRepo.GetAll()
+}
+
+func (u *projectUsecase) Update(project *domain.Project) error {
+	if err := validateProject(project); err != nil {
+		return err
+	}
+	existing, err := u.projectRepo.GetByID(project.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkCustomFieldTypes(existing.CustomFields, project.CustomFields); err != nil {
+		return err
+	}
+	return u.projectRepo.Update(project)
+}
+
+func (u *projectUsecase) Delete(id uint, cascade bool) error {
+	return u.projectRepo.Delete(id, cascade)
+}
+
+func validateProject(project *domain.Project) error {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" || utf8.RuneCountInString(project.Name) > maxProjectNameLength {
+		return domain.ErrInvalidProject
+	}
+	return validateCustomFieldDefinitions(project)
+}
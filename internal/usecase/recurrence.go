@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"go-todo-api/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// recurrenceParts lists the RRULE parts that recurring todos support.
+var recurrenceParts = map[string]bool{
+	"FREQ":     true,
+	"INTERVAL": true,
+	"BYDAY":    true,
+	"COUNT":    true,
+	"UNTIL":    true,
+}
+
+// normalizeRecurrence validates an RFC 5545 RRULE value such as
+// "FREQ=WEEKLY;BYDAY=MO,TH" and returns it upper-cased without the optional
+// "RRULE:" prefix.
+func normalizeRecurrence(rule string) (string, error) {
+	rule = strings.ToUpper(strings.TrimSpace(rule))
+	rule = strings.TrimPrefix(rule, "RRULE:")
+
+	for _, part := range strings.Split(rule, ";") {
+		name, _, _ := strings.Cut(part, "=")
+		if !recurrenceParts[name] {
+			return "", domain.ErrInvalidRecurrence
+		}
+	}
+
+	option, err := rrule.StrToROption(rule)
+	if err != nil {
+		return "", domain.ErrInvalidRecurrence
+	}
+	if option.Interval < 0 || option.Count < 0 {
+		return "", domain.ErrInvalidRecurrence
+	}
+	if _, err := rrule.NewRRule(*option); err != nil {
+		return "", domain.ErrInvalidRecurrence
+	}
+	return rule, nil
+}
+
+// recurrenceLocation returns the time zone a recurrence rule is evaluated
+// in, which is UTC unless the todo names one.
+func recurrenceLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	// Local would depend on where the server runs.
+	if name == "Local" {
+		return nil, domain.ErrInvalidTimezone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, domain.ErrInvalidTimezone
+	}
+	return location, nil
+}
+
+// nextOccurrence returns the due date of the occurrence that follows the
+// todo's current one, or false once the series has ended.
+//
+// Every occurrence is a separate todo, so the rule is evaluated starting
+// from the current due date and COUNT is checked against the todo's
+// occurrence number rather than by rrule itself. The due date is taken in
+// the todo's recurrence time zone, so that weekdays and times of day are
+// those of the user rather than of UTC.
+func nextOccurrence(todo *domain.Todo) (time.Time, bool, error) {
+	option, err := rrule.StrToROption(todo.Recurrence)
+	if err != nil {
+		return time.Time{}, false, domain.ErrInvalidRecurrence
+	}
+	location, err := recurrenceLocation(todo.RecurrenceTimezone)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	dueAt := todo.DueAt.In(location)
+	if option.Count > 0 && todo.Occurrence >= option.Count {
+		return time.Time{}, false, nil
+	}
+
+	option.Count = 0
+	option.Dtstart = dueAt
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return time.Time{}, false, domain.ErrInvalidRecurrence
+	}
+
+	next := rule.After(dueAt, false)
+	if next.IsZero() {
+		return time.Time{}, false, nil
+	}
+	return next, true, nil
+}
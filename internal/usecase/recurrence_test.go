@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-todo-api/internal/domain"
+)
+
+func TestNormalizeRecurrence(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{rule: "FREQ=WEEKLY;BYDAY=MO,TH", want: "FREQ=WEEKLY;BYDAY=MO,TH"},
+		{rule: " rrule:freq=daily;interval=2 ", want: "FREQ=DAILY;INTERVAL=2"},
+		{rule: "FREQ=MONTHLY;COUNT=3", want: "FREQ=MONTHLY;COUNT=3"},
+		{rule: "FREQ=DAILY;UNTIL=20261231T000000Z", want: "FREQ=DAILY;UNTIL=20261231T000000Z"},
+		{rule: "FREQ=DAILY;DTSTART=20261018T090000Z", wantErr: true},
+		{rule: "DTSTART:20261018T090000Z\nRRULE:FREQ=DAILY", wantErr: true},
+		{rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{rule: "FREQ=DAILY;", wantErr: true},
+		{rule: "FREQ=FORTNIGHTLY", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=DAILY;COUNT=-1", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=-1", wantErr: true},
+		{rule: "FREQ=DAILY;UNTIL=tomorrow", wantErr: true},
+		{rule: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeRecurrence(tt.rule)
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrInvalidRecurrence) {
+				t.Errorf("normalizeRecurrence(%q) = %q, %v, want %v", tt.rule, got, err, domain.ErrInvalidRecurrence)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeRecurrence(%q) = %q, %v, want %q", tt.rule, got, err, tt.want)
+		}
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		rule       string
+		dueAt      time.Time
+		occurrence int
+		want       time.Time
+		wantEnded  bool
+	}{
+		{
+			name:       "daily",
+			rule:       "FREQ=DAILY",
+			dueAt:      at(time.October, 18, 9),
+			occurrence: 1,
+			want:       at(time.October, 19, 9),
+		},
+		{
+			name:       "weekdays from a Friday",
+			rule:       "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			dueAt:      at(time.October, 16, 9),
+			occurrence: 1,
+			want:       at(time.October, 19, 9),
+		},
+		{
+			name:       "interval",
+			rule:       "FREQ=WEEKLY;INTERVAL=2",
+			dueAt:      at(time.October, 18, 9),
+			occurrence: 1,
+			want:       at(time.November, 1, 9),
+		},
+		{
+			name:       "starts from the due date",
+			rule:       "FREQ=MONTHLY",
+			dueAt:      at(time.January, 31, 17),
+			occurrence: 1,
+			want:       at(time.March, 31, 17),
+		},
+		{
+			name:       "count left",
+			rule:       "FREQ=DAILY;COUNT=3",
+			dueAt:      at(time.October, 19, 9),
+			occurrence: 2,
+			want:       at(time.October, 20, 9),
+		},
+		{
+			name:       "count exhausted",
+			rule:       "FREQ=DAILY;COUNT=3",
+			dueAt:      at(time.October, 20, 9),
+			occurrence: 3,
+			wantEnded:  true,
+		},
+		{
+			name:       "count of a later occurrence",
+			rule:       "FREQ=DAILY;COUNT=3",
+			dueAt:      at(time.November, 1, 9),
+			occurrence: 2,
+			want:       at(time.November, 2, 9),
+		},
+		{
+			name:       "until reached exactly",
+			rule:       "FREQ=DAILY;UNTIL=20261020T090000Z",
+			dueAt:      at(time.October, 19, 9),
+			occurrence: 2,
+			want:       at(time.October, 20, 9),
+		},
+		{
+			name:       "until exhausted",
+			rule:       "FREQ=DAILY;UNTIL=20261020T090000Z",
+			dueAt:      at(time.October, 20, 9),
+			occurrence: 3,
+			wantEnded:  true,
+		},
+		{
+			name:       "until before the next occurrence",
+			rule:       "FREQ=WEEKLY;UNTIL=20261024T000000Z",
+			dueAt:      at(time.October, 18, 9),
+			occurrence: 1,
+			wantEnded:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := &domain.Todo{Recurrence: tt.rule, DueAt: &tt.dueAt, Occurrence: tt.occurrence}
+			got, ok, err := nextOccurrence(todo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok == tt.wantEnded {
+				t.Fatalf("nextOccurrence() = %v, %v, want the series ended: %v", got, ok, tt.wantEnded)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("nextOccurrence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// occurrenceRepo holds a single todo and records its updates.
+type occurrenceRepo struct {
+	domain.TodoRepository
+	todo    domain.Todo
+	updates int
+}
+
+func (r *occurrenceRepo) GetByID(id uint) (*domain.Todo, error) {
+	todo := r.todo
+	return &todo, nil
+}
+
+func (r *occurrenceRepo) Update(todo *domain.Todo) error {
+	r.todo = *todo
+	r.updates++
+	return nil
+}
+
+func TestSkipOccurrence(t *testing.T) {
+	dueAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	next := dueAt.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name           string
+		todo           domain.Todo
+		wantErr        error
+		wantDue        time.Time
+		wantOccurrence int
+	}{
+		{
+			name:           "next occurrence",
+			todo:           domain.Todo{ID: 1, Recurrence: "FREQ=DAILY;COUNT=3", DueAt: &dueAt, Occurrence: 2},
+			wantDue:        next,
+			wantOccurrence: 3,
+		},
+		{
+			name:    "last occurrence",
+			todo:    domain.Todo{ID: 1, Recurrence: "FREQ=DAILY;COUNT=3", DueAt: &dueAt, Occurrence: 3},
+			wantErr: domain.ErrRecurrenceEnded,
+		},
+		{
+			name:    "past until",
+			todo:    domain.Todo{ID: 1, Recurrence: "FREQ=DAILY;UNTIL=20261019T090000Z", DueAt: &dueAt, Occurrence: 1},
+			wantErr: domain.ErrRecurrenceEnded,
+		},
+		{
+			name:    "completed occurrence",
+			todo:    domain.Todo{ID: 1, Recurrence: "FREQ=DAILY", DueAt: &dueAt, Occurrence: 1, Completed: true},
+			wantErr: domain.ErrOccurrenceDone,
+		},
+		{
+			name:    "not recurring",
+			todo:    domain.Todo{ID: 1, DueAt: &dueAt},
+			wantErr: domain.ErrNotRecurring,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &occurrenceRepo{todo: tt.todo}
+			u := &todoUsecase{todoRepo: repo, workflow: domain.DefaultWorkflow()}
+
+			todo, err := u.SkipOccurrence(1)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SkipOccurrence() error = %v, want %v", err, tt.wantErr)
+				}
+				if repo.updates != 0 {
+					t.Errorf("todo was updated %d times, want it unchanged", repo.updates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !todo.DueAt.Equal(tt.wantDue) || todo.Occurrence != tt.wantOccurrence {
+				t.Errorf("SkipOccurrence() = due %v, occurrence %d, want due %v, occurrence %d",
+					todo.DueAt, todo.Occurrence, tt.wantDue, tt.wantOccurrence)
+			}
+			if !repo.todo.DueAt.Equal(tt.wantDue) || repo.todo.Occurrence != tt.wantOccurrence {
+				t.Errorf("stored due %v, occurrence %d, want due %v, occurrence %d",
+					repo.todo.DueAt, repo.todo.Occurrence, tt.wantDue, tt.wantOccurrence)
+			}
+		})
+	}
+}
+
+func TestNextOccurrenceInTimezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		timezone string
+		dueAt    time.Time
+		want     time.Time
+	}{
+		{
+			// 00:30 on Monday in Berlin is still Sunday in UTC.
+			name:     "Monday night east of UTC",
+			rule:     "FREQ=WEEKLY;BYDAY=MO",
+			timezone: "Europe/Berlin",
+			dueAt:    time.Date(2026, 10, 19, 0, 30, 0, 0, berlin),
+			want:     time.Date(2026, 10, 26, 0, 30, 0, 0, berlin),
+		},
+		{
+			// 22:00 on Monday in New York is 02:00 UTC on Tuesday.
+			name:     "Monday evening west of UTC",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,TH",
+			timezone: "America/New_York",
+			dueAt:    time.Date(2026, 10, 19, 22, 0, 0, 0, newYork),
+			want:     time.Date(2026, 10, 22, 22, 0, 0, 0, newYork),
+		},
+		{
+			name:     "same time of day across a change to winter time",
+			rule:     "FREQ=DAILY",
+			timezone: "Europe/Berlin",
+			dueAt:    time.Date(2026, 10, 24, 9, 0, 0, 0, berlin),
+			want:     time.Date(2026, 10, 25, 9, 0, 0, 0, berlin),
+		},
+		{
+			// Without a time zone the weekdays are those of UTC, which
+			// puts the next occurrence on Wednesday evening in New York.
+			name:  "UTC without a time zone",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,TH",
+			dueAt: time.Date(2026, 10, 19, 22, 0, 0, 0, newYork),
+			want:  time.Date(2026, 10, 22, 2, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Due dates come back from the database in UTC.
+			dueAt := tt.dueAt.UTC()
+			todo := &domain.Todo{Recurrence: tt.rule, RecurrenceTimezone: tt.timezone, DueAt: &dueAt, Occurrence: 1}
+			got, ok, err := nextOccurrence(todo)
+			if err != nil || !ok {
+				t.Fatalf("nextOccurrence() = %v, %v, %v", got, ok, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextOccurrence() = %v, want %v", got.UTC(), tt.want.UTC())
+			}
+		})
+	}
+}
+
+func TestRecurrenceLocation(t *testing.T) {
+	for _, name := range []string{"", "UTC", "Europe/Berlin"} {
+		if _, err := recurrenceLocation(name); err != nil {
+			t.Errorf("recurrenceLocation(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"Local", "Mars/Olympus", "europe/berlin "} {
+		if _, err := recurrenceLocation(name); !errors.Is(err, domain.ErrInvalidTimezone) {
+			t.Errorf("recurrenceLocation(%q) error = %v, want %v", name, err, domain.ErrInvalidTimezone)
+		}
+	}
+}
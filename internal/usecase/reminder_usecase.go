@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-todo-api/internal/domain"
+	"log"
+	"time"
+)
+
+const (
+	// reminderLateAfter is how far behind schedule a reminder may be
+	// delivered before the notification is flagged as late.
+	reminderLateAfter = 2 * time.Minute
+	// maxReminderAttempts is how often delivery of a reminder is tried
+	// before it is marked as failed.
+	maxReminderAttempts = 5
+	// maxRemindersPerRun bounds the work of a single FireDue call so that a
+	// large backlog after downtime is spread over several runs.
+	maxRemindersPerRun = 100
+)
+
+type reminderUsecase struct {
+	reminderRepo domain.ReminderRepository
+	todoRepo     domain.TodoRepository
+	notifier     domain.Notifier
+	missedAfter  time.Duration
+}
+
+// NewReminderUsecase creates the reminder usecase. Reminders that come due
+// more than missedAfter before they can be delivered, e.g. because the server
+// was down, are marked as missed instead of being sent.
+func NewReminderUsecase(
+	repo domain.ReminderRepository,
+	todoRepo domain.TodoRepository,
+	notifier domain.Notifier,
+	missedAfter time.Duration,
+) domain.ReminderUsecase {
+	return &reminderUsecase{
+		reminderRepo: repo,
+		todoRepo:     todoRepo,
+		notifier:     notifier,
+		missedAfter:  missedAfter,
+	}
+}
+
+func (u *reminderUsecase) Create(reminder *domain.Reminder) error {
+	if (reminder.RemindAt == nil) == (reminder.OffsetMinutes == nil) {
+		return domain.ErrInvalidReminder
+	}
+	if validateDueAt(reminder.RemindAt) != nil {
+		return domain.ErrInvalidReminder
+	}
+	if reminder.OffsetMinutes != nil && *reminder.OffsetMinutes < 0 {
+		return domain.ErrInvalidReminderOffset
+	}
+
+	todo, err := u.todoRepo.GetByID(reminder.TodoID)
+	if err != nil {
+		return err
+	}
+
+	if reminder.RemindAt != nil {
+		fireAt := *reminder.RemindAt
+		reminder.FireAt = &fireAt
+	} else {
+		if todo.DueAt == nil {
+			return domain.ErrReminderNeedsDue
+		}
+		fireAt := todo.DueAt.Add(-time.Duration(*reminder.OffsetMinutes) * time.Minute)
+		reminder.FireAt = &fireAt
+	}
+
+	reminder.Status = domain.ReminderPending
+	reminder.Attempts = 0
+	reminder.NextAttemptAt = nil
+	reminder.LastError = ""
+	reminder.FiredAt = nil
+	return u.reminderRepo.Create(reminder)
+}
+
+func (u *reminderUsecase) GetByTodoID(todoID uint) ([]*domain.Reminder, error) {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return nil, err
+	}
+	return u.reminderRepo.GetByTodoID(todoID)
+}
+
+func (u *reminderUsecase) Delete(todoID, id uint) error {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return err
+	}
+	reminder, err := u.reminderRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if reminder.TodoID != todoID {
+		return domain.ErrNotFound
+	}
+	return u.reminderRepo.Delete(id)
+}
+
+func (u *reminderUsecase) FireDue(ctx context.Context, now time.Time) (int, error) {
+	handled := 0
+	for handled < maxRemindersPerRun && ctx.Err() == nil {
+		found, err := u.reminderRepo.ProcessDue(now, func(reminder *domain.Reminder) error {
+			return u.fire(ctx, reminder, now)
+		})
+		if err != nil {
+			return handled, err
+		}
+		if !found {
+			break
+		}
+		handled++
+	}
+	return handled, nil
+}
+
+// fire delivers a single due reminder and records the outcome on it.
+// Reminders of todos that are already done or deleted for good are skipped,
+// and reminders that are overdue by more than missedAfter are dropped rather
+// than sent hours or days late. Failed deliveries are retried with a growing
+// delay. If the todo cannot be read, the reminder stays pending and the
+// error is returned, so that the next run tries again.
+func (u *reminderUsecase) fire(ctx context.Context, reminder *domain.Reminder, now time.Time) error {
+	todo, err := u.todoRepo.GetByID(reminder.TodoID)
+	if errors.Is(err, domain.ErrNotFound) || err == nil && todo.Completed {
+		reminder.Status = domain.ReminderSkipped
+		reminder.FiredAt = &now
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// A reminder created for a time that has already passed is due right
+	// away, so its lateness is measured from when it was created.
+	scheduled := *reminder.FireAt
+	if reminder.CreatedAt.After(scheduled) {
+		scheduled = reminder.CreatedAt
+	}
+	lateness := now.Sub(scheduled)
+	if lateness > u.missedAfter {
+		reminder.Status = domain.ReminderMissed
+		reminder.FiredAt = &now
+		return nil
+	}
+
+	todo.Overdue = todo.IsOverdue(now)
+	notification := domain.Notification{
+		Reminder: *reminder,
+		Todo:     *todo,
+		Late:     lateness > reminderLateAfter,
+	}
+	reminder.Attempts++
+	if err := u.notifier.Notify(ctx, notification); err != nil {
+		log.Printf("reminder %d: delivery attempt %d failed: %v", reminder.ID, reminder.Attempts, err)
+		reminder.LastError = err.Error()
+		if reminder.Attempts >= maxReminderAttempts {
+			reminder.Status = domain.ReminderFailed
+			return nil
+		}
+		retryAt := now.Add(time.Duration(reminder.Attempts*reminder.Attempts) * time.Minute)
+		reminder.NextAttemptAt = &retryAt
+		return nil
+	}
+
+	reminder.Status = domain.ReminderSent
+	reminder.NextAttemptAt = nil
+	reminder.LastError = ""
+	reminder.FiredAt = &now
+	return nil
+}
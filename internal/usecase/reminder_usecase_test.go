@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-todo-api/internal/domain"
+)
+
+// dueReminderRepo holds a single due reminder and stores the changes of
+// ProcessDue only when handle succeeds, like the database transaction does.
+type dueReminderRepo struct {
+	domain.ReminderRepository
+	reminder domain.Reminder
+}
+
+func (r *dueReminderRepo) ProcessDue(now time.Time, handle func(reminder *domain.Reminder) error) (bool, error) {
+	if r.reminder.Status != domain.ReminderPending {
+		return false, nil
+	}
+	reminder := r.reminder
+	if err := handle(&reminder); err != nil {
+		return true, err
+	}
+	r.reminder = reminder
+	return true, nil
+}
+
+// stubTodoRepo returns todo or err from GetByID.
+type stubTodoRepo struct {
+	domain.TodoRepository
+	todo *domain.Todo
+	err  error
+}
+
+func (r *stubTodoRepo) GetByID(id uint) (*domain.Todo, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	todo := *r.todo
+	return &todo, nil
+}
+
+type recordingNotifier struct {
+	notifications []domain.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func TestFireDue(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	fireAt := now.Add(-time.Minute)
+	errDatabase := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		todo       *domain.Todo
+		todoErr    error
+		wantErr    error
+		wantStatus domain.ReminderStatus
+		wantSent   int
+	}{
+		{
+			name:       "open todo",
+			todo:       &domain.Todo{ID: 1},
+			wantStatus: domain.ReminderSent,
+			wantSent:   1,
+		},
+		{
+			name:       "completed todo",
+			todo:       &domain.Todo{ID: 1, Completed: true},
+			wantStatus: domain.ReminderSkipped,
+		},
+		{
+			name:       "todo deleted for good",
+			todoErr:    domain.ErrNotFound,
+			wantStatus: domain.ReminderSkipped,
+		},
+		{
+			name:       "database error",
+			todoErr:    errDatabase,
+			wantErr:    errDatabase,
+			wantStatus: domain.ReminderPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminders := &dueReminderRepo{reminder: domain.Reminder{
+				ID:        1,
+				TodoID:    1,
+				FireAt:    &fireAt,
+				Status:    domain.ReminderPending,
+				CreatedAt: fireAt.Add(-time.Hour),
+			}}
+			notifier := &recordingNotifier{}
+			u := NewReminderUsecase(reminders, &stubTodoRepo{todo: tt.todo, err: tt.todoErr}, notifier, time.Hour)
+
+			_, err := u.FireDue(context.Background(), now)
+			if !errors.Is(err, tt.wantErr) || err != nil && tt.wantErr == nil {
+				t.Fatalf("FireDue() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := reminders.reminder.Status; got != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got, tt.wantStatus)
+			}
+			if tt.wantStatus == domain.ReminderPending && (reminders.reminder.FiredAt != nil || reminders.reminder.Attempts != 0) {
+				t.Errorf("pending reminder was changed: %+v", reminders.reminder)
+			}
+			if got := len(notifier.notifications); got != tt.wantSent {
+				t.Errorf("sent %d notifications, want %d", got, tt.wantSent)
+			}
+		})
+	}
+}
+
+func (r *dueReminderRepo) GetByID(id uint) (*domain.Reminder, error) {
+	if id != r.reminder.ID {
+		return nil, domain.ErrNotFound
+	}
+	reminder := r.reminder
+	return &reminder, nil
+}
+
+func (r *dueReminderRepo) Delete(id uint) error {
+	r.reminder = domain.Reminder{}
+	return nil
+}
+
+func TestDeleteReminder(t *testing.T) {
+	tests := []struct {
+		name    string
+		todoID  uint
+		trashed bool
+		wantErr error
+	}{
+		{name: "reminder of the todo", todoID: 1},
+		{name: "reminder of another todo", todoID: 2, wantErr: domain.ErrNotFound},
+		{name: "todo in the trash", todoID: 1, trashed: true, wantErr: domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminders := &dueReminderRepo{reminder: domain.Reminder{ID: 3, TodoID: 1, Status: domain.ReminderPending}}
+			todos := &trashTodoRepo{trashed: map[uint]bool{1: tt.trashed}}
+			u := NewReminderUsecase(reminders, todos, &recordingNotifier{}, time.Hour)
+
+			err := u.Delete(tt.todoID, 3)
+			if !errors.Is(err, tt.wantErr) || err != nil && tt.wantErr == nil {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+			if kept := reminders.reminder.ID == 3; kept != (tt.wantErr != nil) {
+				t.Errorf("reminder kept: %v, want %v", kept, tt.wantErr != nil)
+			}
+		})
+	}
+}
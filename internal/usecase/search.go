@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"time"
+
+	"go-todo-api/internal/domain"
+)
+
+func (u *todoUsecase) Search(query domain.SearchQuery) ([]*domain.SearchResult, error) {
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultPageLimit
+	}
+	if query.Limit < 0 || query.Limit > domain.MaxPageLimit {
+		return nil, domain.ErrInvalidLimit
+	}
+	terms, err := domain.ParseSearchTerms(query.Text)
+	if err != nil {
+		return nil, err
+	}
+	query.Terms = terms
+
+	results, err := u.todoRepo.Search(query)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, result := range results {
+		result.Todo.Overdue = result.Todo.IsOverdue(now)
+	}
+	return results, nil
+}
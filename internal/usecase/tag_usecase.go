@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"go-todo-api/internal/domain"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxTagNameLength = 50
+
+type tagUsecase struct {
+	tagRepo domain.TagRepository
+}
+
+func NewTagUsecase(repo domain.TagRepository) domain.TagUsecase {
+	return &tagUsecase{
+		tagRepo: repo,
+	}
+}
+
+func (u *tagUsecase) Create(tag *domain.Tag) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+	return u.tagRepo.Create(tag)
+}
+
+func (u *tagUsecase) GetByID(id uint) (*domain.Tag, error) {
+	return u.tagRepo.GetByID(id)
+}
+
+func (u *tagUsecase) GetAll() ([]*domain.Tag, error) {
+	return u.tagRepo.GetAll()
+}
+
+func (u *tagUsecase) Update(tag *domain.Tag) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+	return u.tagRepo.Update(tag)
+}
+
+func (u *tagUsecase) Delete(id uint) error {
+	return u.tagRepo.Delete(id)
+}
+
+func validateTag(tag *domain.Tag) error {
+	tag.Name = strings.TrimSpace(tag.Name)
+	if tag.Name == "" || utf8.RuneCountInString(tag.Name) > maxTagNameLength {
+		return domain.ErrInvalidTagName
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"errors"
+	"go-todo-api/internal/domain"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxTimeEntryUserLength = 100
+
+// timeEntryClockSkew is how far in the future a manual entry may end, to
+// allow for clients whose clocks run slightly ahead.
+const timeEntryClockSkew = time.Minute
+
+type timeEntryUsecase struct {
+	timeEntryRepo domain.TimeEntryRepository
+	todoRepo      domain.TodoRepository
+}
+
+func NewTimeEntryUsecase(repo domain.TimeEntryRepository, todoRepo domain.TodoRepository) domain.TimeEntryUsecase {
+	return &timeEntryUsecase{
+		timeEntryRepo: repo,
+		todoRepo:      todoRepo,
+	}
+}
+
+func (u *timeEntryUsecase) StartTimer(todoID uint, user string) (*domain.TimeEntry, error) {
+	user, err := normalizeTimeEntryUser(user)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return nil, err
+	}
+
+	entry := &domain.TimeEntry{
+		TodoID:    todoID,
+		User:      user,
+		StartedAt: time.Now(),
+	}
+	if err := u.timeEntryRepo.Create(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (u *timeEntryUsecase) StopTimer(todoID uint, user string) (*domain.TimeEntry, error) {
+	user, err := normalizeTimeEntryUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// The todo is only looked up when no timer is running on it, so that a
+	// timer left running on a todo that went to the trash can be stopped.
+	// Otherwise it would keep the user from starting another one.
+	now := time.Now()
+	entry, err := u.timeEntryRepo.Stop(todoID, user, now)
+	if errors.Is(err, domain.ErrNotFound) {
+		if _, err := u.todoRepo.GetByID(todoID); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrNoTimerRunning
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry.DurationMinutes = int(entry.Duration(now).Minutes())
+	return entry, nil
+}
+
+func (u *timeEntryUsecase) Create(entry *domain.TimeEntry) error {
+	user, err := normalizeTimeEntryUser(entry.User)
+	if err != nil {
+		return err
+	}
+	entry.User = user
+
+	now := time.Now()
+	if validateDueAt(&entry.StartedAt) != nil || entry.EndedAt == nil || validateDueAt(entry.EndedAt) != nil {
+		return domain.ErrInvalidTimeEntry
+	}
+	if entry.EndedAt.Before(entry.StartedAt) || entry.EndedAt.After(now.Add(timeEntryClockSkew)) {
+		return domain.ErrInvalidTimeEntry
+	}
+	if _, err := u.todoRepo.GetByID(entry.TodoID); err != nil {
+		return err
+	}
+
+	entry.Manual = true
+	if err := u.timeEntryRepo.Create(entry); err != nil {
+		return err
+	}
+	entry.DurationMinutes = int(entry.Duration(now).Minutes())
+	return nil
+}
+
+func (u *timeEntryUsecase) GetByTodoID(todoID uint) ([]*domain.TimeEntry, error) {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return nil, err
+	}
+
+	entries, err := u.timeEntryRepo.GetByTodoID(todoID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		entry.DurationMinutes = int(entry.Duration(now).Minutes())
+	}
+	return entries, nil
+}
+
+func (u *timeEntryUsecase) Delete(todoID, id uint) error {
+	if _, err := u.todoRepo.GetByID(todoID); err != nil {
+		return err
+	}
+	entry, err := u.timeEntryRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if entry.TodoID != todoID {
+		return domain.ErrNotFound
+	}
+	return u.timeEntryRepo.Delete(id)
+}
+
+func (u *timeEntryUsecase) Report(filter domain.TimeReportFilter) (*domain.TimeReport, error) {
+	if !filter.From.Before(filter.To) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+	return u.timeEntryRepo.Report(filter)
+}
+
+func normalizeTimeEntryUser(user string) (string, error) {
+	user = strings.TrimSpace(user)
+	if user == "" || utf8.RuneCountInString(user) > maxTimeEntryUserLength {
+		return "", domain.ErrInvalidTimeEntryUser
+	}
+	return user, nil
+}
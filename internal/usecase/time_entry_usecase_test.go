@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-todo-api/internal/domain"
+)
+
+// timeEntryRepo keeps time entries in memory and allows one running entry
+// per user, like the partial unique index on running entries.
+type timeEntryRepo struct {
+	domain.TimeEntryRepository
+	entries []domain.TimeEntry
+}
+
+func (r *timeEntryRepo) Create(entry *domain.TimeEntry) error {
+	for _, other := range r.entries {
+		if entry.EndedAt == nil && other.EndedAt == nil && other.User == entry.User {
+			return domain.ErrTimerRunning
+		}
+	}
+	entry.ID = uint(len(r.entries) + 1)
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *timeEntryRepo) Stop(todoID uint, user string, endedAt time.Time) (*domain.TimeEntry, error) {
+	for i, entry := range r.entries {
+		if entry.TodoID == todoID && entry.User == user && entry.EndedAt == nil {
+			r.entries[i].EndedAt = &endedAt
+			stopped := r.entries[i]
+			return &stopped, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// trashTodoRepo holds todos that are either live or in the trash, where
+// GetByID no longer finds them.
+type trashTodoRepo struct {
+	domain.TodoRepository
+	trashed map[uint]bool
+}
+
+func (r *trashTodoRepo) GetByID(id uint) (*domain.Todo, error) {
+	if id == 0 || r.trashed[id] {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.Todo{ID: id}, nil
+}
+
+func TestStopTimerOfTrashedTodo(t *testing.T) {
+	todos := &trashTodoRepo{trashed: make(map[uint]bool)}
+	entries := &timeEntryRepo{}
+	u := NewTimeEntryUsecase(entries, todos)
+
+	if _, err := u.StartTimer(1, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	todos.trashed[1] = true
+
+	if _, err := u.StartTimer(2, "alice"); !errors.Is(err, domain.ErrTimerRunning) {
+		t.Fatalf("StartTimer() on another todo error = %v, want %v", err, domain.ErrTimerRunning)
+	}
+	entry, err := u.StopTimer(1, "alice")
+	if err != nil {
+		t.Fatalf("StopTimer() on the trashed todo error = %v", err)
+	}
+	if entry.EndedAt == nil {
+		t.Error("StopTimer() returned a running entry")
+	}
+	if _, err := u.StartTimer(2, "alice"); err != nil {
+		t.Fatalf("StartTimer() after stopping error = %v", err)
+	}
+	if _, err := u.StopTimer(1, "alice"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("StopTimer() again on the trashed todo error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
+
+func TestStopTimer(t *testing.T) {
+	tests := []struct {
+		name    string
+		todoID  uint
+		user    string
+		wantErr error
+	}{
+		{name: "running timer", todoID: 1, user: "alice"},
+		{name: "timer of another user", todoID: 1, user: "bob", wantErr: domain.ErrNoTimerRunning},
+		{name: "timer on another todo", todoID: 2, user: "alice", wantErr: domain.ErrNoTimerRunning},
+		{name: "todo that does not exist", todoID: 0, user: "alice", wantErr: domain.ErrNotFound},
+		{name: "no user", todoID: 1, user: " ", wantErr: domain.ErrInvalidTimeEntryUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewTimeEntryUsecase(&timeEntryRepo{}, &trashTodoRepo{})
+			if _, err := u.StartTimer(1, "alice"); err != nil {
+				t.Fatal(err)
+			}
+			_, err := u.StopTimer(tt.todoID, tt.user)
+			if !errors.Is(err, tt.wantErr) || err != nil && tt.wantErr == nil {
+				t.Errorf("StopTimer(%d, %q) error = %v, want %v", tt.todoID, tt.user, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func (r *timeEntryRepo) GetByID(id uint) (*domain.TimeEntry, error) {
+	for _, entry := range r.entries {
+		if entry.ID == id {
+			return &entry, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *timeEntryRepo) Delete(id uint) error {
+	for i, entry := range r.entries {
+		if entry.ID == id {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func TestDeleteTimeEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		todoID  uint
+		trashed bool
+		wantErr error
+	}{
+		{name: "entry of the todo", todoID: 1},
+		{name: "entry of another todo", todoID: 2, wantErr: domain.ErrNotFound},
+		{name: "todo in the trash", todoID: 1, trashed: true, wantErr: domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := &timeEntryRepo{}
+			todos := &trashTodoRepo{trashed: make(map[uint]bool)}
+			u := NewTimeEntryUsecase(entries, todos)
+			ended := time.Now().Add(-time.Minute)
+			entry := &domain.TimeEntry{TodoID: 1, User: "alice", StartedAt: ended.Add(-time.Hour), EndedAt: &ended}
+			if err := u.Create(entry); err != nil {
+				t.Fatal(err)
+			}
+			todos.trashed[1] = tt.trashed
+
+			err := u.Delete(tt.todoID, entry.ID)
+			if !errors.Is(err, tt.wantErr) || err != nil && tt.wantErr == nil {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+			if kept := len(entries.entries) == 1; kept != (tt.wantErr != nil) {
+				t.Errorf("entry kept: %v, want %v", kept, tt.wantErr != nil)
+			}
+		})
+	}
+}
+
+func TestNormalizeTimeEntryUser(t *testing.T) {
+	tests := []struct {
+		user    string
+		want    string
+		wantErr bool
+	}{
+		{user: " alice ", want: "alice"},
+		{user: strings.Repeat("ü", maxTimeEntryUserLength), want: strings.Repeat("ü", maxTimeEntryUserLength)},
+		{user: strings.Repeat("ü", maxTimeEntryUserLength+1), wantErr: true},
+		{user: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeTimeEntryUser(tt.user)
+		if tt.wantErr != errors.Is(err, domain.ErrInvalidTimeEntryUser) || got != tt.want {
+			t.Errorf("normalizeTimeEntryUser(%q) = %q, %v, want %q", tt.user, got, err, tt.want)
+		}
+	}
+}
@@ -1,35 +1,636 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
 	"go-todo-api/internal/domain"
+	"time"
 )
 
+// maxTodoDepth is the number of levels a todo hierarchy may have, counting
+// the top-level todo as the first.
+const maxTodoDepth = 5
+
 type todoUsecase struct {
-	todoRepo domain.TodoRepository
+	todoRepo    domain.TodoRepository
+	tagRepo     domain.TagRepository
+	projectRepo domain.ProjectRepository
+	workflow    *domain.Workflow
+	renderer    domain.MarkdownRenderer
 }
 
-func NewTodoUsecase(repo domain.TodoRepository) domain.TodoUsecase {
+// NewTodoUsecase creates the todo usecase. workflow defines the statuses of
+// todos and must be valid.
+func NewTodoUsecase(
+	repo domain.TodoRepository,
+	tagRepo domain.TagRepository,
+	projectRepo domain.ProjectRepository,
+	workflow *domain.Workflow,
+	renderer domain.MarkdownRenderer,
+) domain.TodoUsecase {
 	return &todoUsecase{
-		todoRepo: repo,
+		todoRepo:    repo,
+		tagRepo:     tagRepo,
+		projectRepo: projectRepo,
+		workflow:    workflow,
+		renderer:    renderer,
 	}
 }
 
 func (u *todoUsecase) Create(todo *domain.Todo) error {
-	return u.todoRepo.Create(todo)
+	if err := validateTodo(todo); err != nil {
+		return err
+	}
+	if err := u.resolveStatus(todo, nil); err != nil {
+		return err
+	}
+	if err := normalizeChecklist(todo); err != nil {
+		return err
+	}
+	if err := u.resolveTags(todo); err != nil {
+		return err
+	}
+	if err := u.checkProject(todo, nil); err != nil {
+		return err
+	}
+	if err := u.checkParent(todo); err != nil {
+		return err
+	}
+	todo.Occurrence = 0
+	if todo.Recurrence != "" {
+		todo.Occurrence = 1
+	}
+	todo.NextOccurrenceID = nil
+	todo.ArchivedAt = nil
+	position, err := u.lastPosition()
+	if err != nil {
+		return err
+	}
+	todo.Position = position
+	if err := u.todoRepo.Create(todo); err != nil {
+		return err
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return nil
 }
 
 func (u *todoUsecase) GetByID(id uint) (*domain.Todo, error) {
-	return u.todoRepo.GetByID(id)
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.loadLinks(todo); err != nil {
+		return nil, err
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return todo, nil
+}
+
+func (u *todoUsecase) GetAll(filter domain.TodoFilter, page domain.PageRequest) (*domain.TodoPage, error) {
+	if page.Limit == 0 {
+		page.Limit = domain.DefaultPageLimit
+	}
+	if page.Limit < 0 || page.Limit > domain.MaxPageLimit {
+		return nil, domain.ErrInvalidLimit
+	}
+	if filter.Query != "" {
+		if err := domain.ParseTodoQuery(filter.Query, &filter, u.workflow); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	if err := resolveDueFilter(&filter, now); err != nil {
+		return nil, err
+	}
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+	for _, status := range filter.Statuses {
+		if _, ok := u.workflow.State(status); !ok {
+			return nil, domain.ErrInvalidStatus
+		}
+	}
+	if err := u.resolveCustomFieldFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	result, err := u.todoRepo.GetAll(filter, page)
+	if err != nil {
+		return nil, err
+	}
+	for _, todo := range result.Todos {
+		todo.Overdue = todo.IsOverdue(now)
+	}
+	return result, nil
 }
 
-func (u *todoUsecase) GetAll() ([]*domain.Todo, error) {
-	return u.todoRepo.GetAll()
+func (u *todoUsecase) GetChildren(id uint) ([]*domain.Todo, error) {
+	if _, err := u.todoRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	children, err := u.todoRepo.GetChildren(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, child := range children {
+		child.Overdue = child.IsOverdue(now)
+	}
+	return children, nil
 }
 
+// Update replaces a todo. Status changes must follow the workflow, and a todo
+// cannot move to the done state while it is blocked by open todos. Moving an
+// occurrence of a recurring todo to the done state creates the next
+// occurrence, which the done one links to; cancelling it ends the series.
 func (u *todoUsecase) Update(todo *domain.Todo) error {
-	return u.todoRepo.Update(todo)
+	existing, err := u.prepareUpdate(todo)
+	if err != nil {
+		return err
+	}
+
+	if todo.Status == u.workflow.Done && existing.Status != u.workflow.Done && todo.Recurrence != "" && todo.NextOccurrenceID == nil {
+		next, err := u.newOccurrence(todo)
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			if err := u.todoRepo.CompleteOccurrence(todo, next); err != nil {
+				return err
+			}
+			todo.Overdue = todo.IsOverdue(time.Now())
+			return nil
+		}
+	}
+
+	if err := u.todoRepo.Update(todo); err != nil {
+		return err
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return nil
+}
+
+// prepareUpdate validates the replacement of a stored todo and carries over
+// the fields clients cannot change, without saving it. It returns the stored
+// todo.
+func (u *todoUsecase) prepareUpdate(todo *domain.Todo) (*domain.Todo, error) {
+	existing, err := u.todoRepo.GetByID(todo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTodo(todo); err != nil {
+		return nil, err
+	}
+	if err := u.resolveTags(todo); err != nil {
+		return nil, err
+	}
+	if err := u.checkProject(todo, existing); err != nil {
+		return nil, err
+	}
+	if err := u.checkParent(todo); err != nil {
+		return nil, err
+	}
+	if err := u.resolveStatus(todo, existing); err != nil {
+		return nil, err
+	}
+	if todo.Status == u.workflow.Done && existing.Status != todo.Status && existing.Blocked {
+		return nil, domain.ErrTodoBlocked
+	}
+
+	// The checklist and manual position have endpoints of their own and the
+	// position of a todo in its series is managed here, so none of them is
+	// taken from the client.
+	todo.Checklist = existing.Checklist
+	todo.Position = existing.Position
+	todo.NextOccurrenceID = existing.NextOccurrenceID
+	switch {
+	case todo.Recurrence == existing.Recurrence:
+		todo.Occurrence = existing.Occurrence
+	case todo.Recurrence != "":
+		todo.Occurrence = 1
+	default:
+		todo.Occurrence = 0
+	}
+	return existing, nil
+}
+
+func (u *todoUsecase) SkipOccurrence(id uint) (*domain.Todo, error) {
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if todo.Recurrence == "" {
+		return nil, domain.ErrNotRecurring
+	}
+	if todo.Completed {
+		return nil, domain.ErrOccurrenceDone
+	}
+
+	dueAt, ok, err := nextOccurrence(todo)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrRecurrenceEnded
+	}
+
+	todo.DueAt = &dueAt
+	todo.Occurrence++
+	if err := u.todoRepo.Update(todo); err != nil {
+		return nil, err
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return todo, nil
+}
+
+func (u *todoUsecase) StopRecurrence(id uint) (*domain.Todo, error) {
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if todo.Recurrence == "" {
+		return nil, domain.ErrNotRecurring
+	}
+
+	todo.Recurrence = ""
+	todo.RecurrenceTimezone = ""
+	todo.Occurrence = 0
+	if err := u.todoRepo.Update(todo); err != nil {
+		return nil, err
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return todo, nil
+}
+
+// newOccurrence builds the todo that follows a completed occurrence, or
+// returns nil when the series has ended.
+func (u *todoUsecase) newOccurrence(todo *domain.Todo) (*domain.Todo, error) {
+	dueAt, ok, err := nextOccurrence(todo)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &domain.Todo{
+		Title:                 todo.Title,
+		Description:           todo.Description,
+		Status:                u.workflow.Initial,
+		Priority:              todo.Priority,
+		DueAt:                 &dueAt,
+		Tags:                  todo.Tags,
+		ProjectID:             todo.ProjectID,
+		ParentID:              todo.ParentID,
+		Recurrence:            todo.Recurrence,
+		RecurrenceTimezone:    todo.RecurrenceTimezone,
+		Occurrence:            todo.Occurrence + 1,
+		EstimateMinutes:       todo.EstimateMinutes,
+		CustomFields:          todo.CustomFields,
+		Checklist:             resetChecklist(todo.Checklist),
+		ChecklistAutoComplete: todo.ChecklistAutoComplete,
+		// Sharing the position keeps the next occurrence right behind the
+		// completed one, as ties are ordered by ID.
+		Position: todo.Position,
+	}, nil
+}
+
+func (u *todoUsecase) Delete(id uint, children domain.ChildPolicy) error {
+	switch children {
+	case "":
+		children = domain.ChildrenReparent
+	case domain.ChildrenReparent, domain.ChildrenCascade:
+	default:
+		return domain.ErrInvalidChildren
+	}
+	return u.todoRepo.Delete(id, children)
+}
+
+func (u *todoUsecase) GetHistory(id uint) ([]*domain.TodoEvent, error) {
+	if _, err := u.todoRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return u.todoRepo.GetHistory(id)
+}
+
+func (u *todoUsecase) GetBlockers(id uint) ([]*domain.Todo, error) {
+	if _, err := u.todoRepo.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	blockers, err := u.todoRepo.GetBlockers(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, blocker := range blockers {
+		blocker.Overdue = blocker.IsOverdue(now)
+	}
+	return blockers, nil
 }
 
-func (u *todoUsecase) Delete(id uint) error {
-	return u.todoRepo.Delete(id)
-} 
+func (u *todoUsecase) AddBlocker(dependency *domain.Dependency) error {
+	if _, err := u.todoRepo.GetByID(dependency.TodoID); err != nil {
+		return err
+	}
+	if dependency.BlockedByID == dependency.TodoID {
+		return domain.ErrDependencyCycle
+	}
+	_, err := u.todoRepo.GetByID(dependency.BlockedByID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrUnknownBlocker
+	}
+	if err != nil {
+		return err
+	}
+
+	// The new edge closes a cycle if the blocker already waits for the todo.
+	cycle, err := u.todoRepo.DependsOn(dependency.BlockedByID, dependency.TodoID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return domain.ErrDependencyCycle
+	}
+	return u.todoRepo.AddBlocker(dependency.TodoID, dependency.BlockedByID)
+}
+
+func (u *todoUsecase) RemoveBlocker(dependency *domain.Dependency) error {
+	if _, err := u.todoRepo.GetByID(dependency.TodoID); err != nil {
+		return err
+	}
+	return u.todoRepo.RemoveBlocker(dependency.TodoID, dependency.BlockedByID)
+}
+
+// checkProject verifies that the project a todo is assigned to exists and
+// that the todo's custom field values match the project's definitions.
+// existing is the stored todo when todo replaces it.
+func (u *todoUsecase) checkProject(todo, existing *domain.Todo) error {
+	if todo.ProjectID == nil {
+		return validateCustomFieldValues(todo, existing, nil)
+	}
+	project, err := u.projectRepo.GetByID(*todo.ProjectID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrUnknownProject
+	}
+	if err != nil {
+		return err
+	}
+	return validateCustomFieldValues(todo, existing, project)
+}
+
+// resolveCustomFieldFilter types the custom field filters and sort field of
+// filter using the definitions of the filtered project.
+func (u *todoUsecase) resolveCustomFieldFilter(filter *domain.TodoFilter) error {
+	sortsByCustomField := false
+	for _, key := range filter.Sort {
+		sortsByCustomField = sortsByCustomField || key.CustomField != ""
+	}
+	if len(filter.CustomFields) == 0 && !sortsByCustomField {
+		return nil
+	}
+	if filter.ProjectID == nil {
+		return domain.ErrCustomFieldsNeedProject
+	}
+	project, err := u.projectRepo.GetByID(*filter.ProjectID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrUnknownProject
+	}
+	if err != nil {
+		return err
+	}
+
+	for key, raw := range filter.CustomFields {
+		field, ok := project.CustomFieldDefinitionFor(key)
+		if !ok {
+			return fmt.Errorf("%w: project has no custom field %q", domain.ErrInvalidFilter, key)
+		}
+		value, err := parseCustomFieldValue(field, raw)
+		if err != nil {
+			return err
+		}
+		filter.CustomFieldConditions = append(filter.CustomFieldConditions, domain.CustomFieldCondition{Key: key, Value: value})
+	}
+
+	for i, key := range filter.Sort {
+		if key.CustomField == "" {
+			continue
+		}
+		field, ok := project.CustomFieldDefinitionFor(key.CustomField)
+		if !ok {
+			return fmt.Errorf("%w: project has no custom field %q to sort by", domain.ErrInvalidFilter, key.CustomField)
+		}
+		filter.Sort[i].CustomFieldType = field.Type
+	}
+	return nil
+}
+
+// checkParent verifies that a todo can be nested below its parent without
+// creating a cycle or growing the hierarchy beyond maxTodoDepth.
+func (u *todoUsecase) checkParent(todo *domain.Todo) error {
+	if todo.ParentID == nil {
+		return nil
+	}
+
+	depth := 0
+	for id := todo.ParentID; id != nil; depth++ {
+		if todo.ID != 0 && *id == todo.ID {
+			return domain.ErrTodoCycle
+		}
+		if depth >= maxTodoDepth {
+			return domain.ErrTodoTooDeep
+		}
+		ancestor, err := u.todoRepo.GetByID(*id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrUnknownParent
+		}
+		if err != nil {
+			return err
+		}
+		id = ancestor.ParentID
+	}
+
+	height := 0
+	if todo.ID != 0 {
+		var err error
+		if height, err = u.todoRepo.SubtreeHeight(todo.ID); err != nil {
+			return err
+		}
+	}
+	if depth+1+height > maxTodoDepth {
+		return domain.ErrTodoTooDeep
+	}
+	return nil
+}
+
+// resolveTags replaces the tags sent by the client, which only need an ID,
+// with the stored tags. Unknown IDs are rejected.
+func (u *todoUsecase) resolveTags(todo *domain.Todo) error {
+	if len(todo.Tags) == 0 {
+		todo.Tags = []domain.Tag{}
+		return nil
+	}
+
+	seen := make(map[uint]bool, len(todo.Tags))
+	ids := make([]uint, 0, len(todo.Tags))
+	for _, tag := range todo.Tags {
+		if !seen[tag.ID] {
+			seen[tag.ID] = true
+			ids = append(ids, tag.ID)
+		}
+	}
+
+	tags, err := u.tagRepo.GetByIDs(ids)
+	if err != nil {
+		return err
+	}
+	if len(tags) != len(ids) {
+		return domain.ErrUnknownTag
+	}
+
+	todo.Tags = make([]domain.Tag, len(tags))
+	for i, tag := range tags {
+		todo.Tags[i] = *tag
+	}
+	return nil
+}
+
+// validateTodo checks the user-provided fields of a todo and fills in
+// defaults for the optional ones.
+func validateTodo(todo *domain.Todo) error {
+	if err := validateDueAt(todo.DueAt); err != nil {
+		return err
+	}
+	if todo.EstimateMinutes != nil && *todo.EstimateMinutes < 0 {
+		return domain.ErrInvalidEstimate
+	}
+	if todo.Priority == "" {
+		todo.Priority = domain.PriorityNone
+	}
+	if _, ok := todo.Priority.Rank(); !ok {
+		return domain.ErrInvalidPriority
+	}
+	if todo.Recurrence != "" {
+		rule, err := normalizeRecurrence(todo.Recurrence)
+		if err != nil {
+			return err
+		}
+		if todo.DueAt == nil {
+			return domain.ErrRecurrenceNeedsDue
+		}
+		if _, err := recurrenceLocation(todo.RecurrenceTimezone); err != nil {
+			return err
+		}
+		todo.Recurrence = rule
+	} else {
+		todo.RecurrenceTimezone = ""
+	}
+	return nil
+}
+
+// validateDueAt rejects due dates that did not come from a real timestamp,
+// such as the zero value left behind by an empty or malformed input.
+func validateDueAt(dueAt *time.Time) error {
+	if dueAt == nil {
+		return nil
+	}
+	if dueAt.IsZero() || dueAt.Year() < 1970 || dueAt.Year() > 9999 {
+		return domain.ErrInvalidDueDate
+	}
+	return nil
+}
+
+// resolveDueFilter turns the relative due selection into an absolute range.
+// "today" is evaluated in the filter's location, falling back to UTC.
+func resolveDueFilter(filter *domain.TodoFilter, now time.Time) error {
+	if filter.DueWithinDays < 0 {
+		return fmt.Errorf("%w: due_within must be a positive number of days", domain.ErrInvalidFilter)
+	}
+	if filter.Due != domain.DueAny && filter.DueWithinDays > 0 {
+		return fmt.Errorf("%w: due and due_within cannot be combined", domain.ErrInvalidFilter)
+	}
+	if filter.DueWithinDays > 0 && (filter.DueAfter != nil || filter.DueBefore != nil) {
+		return fmt.Errorf("%w: due_within cannot be combined with a due date range in q", domain.ErrInvalidFilter)
+	}
+
+	loc := filter.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	switch filter.Due {
+	case domain.DueAny:
+		if filter.DueWithinDays > 0 {
+			until := now.AddDate(0, 0, filter.DueWithinDays)
+			filter.DueAfter = &now
+			filter.DueBefore = &until
+			filter.OnlyOpen = true
+		}
+	case domain.DueOverdue:
+		filter.DueBefore = &now
+		filter.OnlyOpen = true
+	case domain.DueToday:
+		local := now.In(loc)
+		start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 0, 1)
+		filter.DueAfter = &start
+		filter.DueBefore = &end
+	default:
+		return fmt.Errorf("%w: due must be overdue or today", domain.ErrInvalidFilter)
+	}
+	return nil
+}
+
+// validateFilter checks the filter values that are passed through to the
+// repository unchanged.
+func validateFilter(filter domain.TodoFilter) error {
+	for _, p := range filter.Priorities {
+		if _, ok := p.Rank(); !ok {
+			return domain.ErrInvalidPriority
+		}
+	}
+	if filter.MinPriority != "" {
+		if _, ok := filter.MinPriority.Rank(); !ok {
+			return domain.ErrInvalidPriority
+		}
+	}
+	switch filter.TagMatch {
+	case "", domain.TagMatchAny, domain.TagMatchAll:
+	default:
+		return fmt.Errorf("%w: tag_match must be any or all", domain.ErrInvalidFilter)
+	}
+	ranges := []struct {
+		name          string
+		after, before *time.Time
+	}{
+		{"completed", filter.CompletedAfter, filter.CompletedBefore},
+		{"created", filter.CreatedAfter, filter.CreatedBefore},
+		{"updated", filter.UpdatedAfter, filter.UpdatedBefore},
+	}
+	for _, r := range ranges {
+		if r.after != nil && r.before != nil && !r.after.Before(*r.before) {
+			return fmt.Errorf("%w: %s_after must be before %s_before", domain.ErrInvalidFilter, r.name, r.name)
+		}
+	}
+	switch filter.Archived {
+	case domain.ArchivedExclude, domain.ArchivedInclude, domain.ArchivedOnly:
+	default:
+		return fmt.Errorf("%w: archived must be include or only", domain.ErrInvalidFilter)
+	}
+	if len(filter.Sort) > domain.MaxSortKeys {
+		return fmt.Errorf("%w: sort can have at most %d fields", domain.ErrInvalidFilter, domain.MaxSortKeys)
+	}
+	seen := make(map[domain.SortKey]bool, len(filter.Sort))
+	for _, key := range filter.Sort {
+		if (key.Field == "") == (key.CustomField == "") {
+			return fmt.Errorf("%w: sort fields must not be empty", domain.ErrInvalidFilter)
+		}
+		field := domain.SortKey{Field: key.Field, CustomField: key.CustomField}
+		if seen[field] {
+			name := key.Field
+			if key.CustomField != "" {
+				name = "cf." + key.CustomField
+			}
+			return fmt.Errorf("%w: sort lists %s twice", domain.ErrInvalidFilter, name)
+		}
+		seen[field] = true
+	}
+	return nil
+}
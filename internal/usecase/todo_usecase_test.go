@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go-todo-api/internal/domain"
+)
+
+func TestResolveDueFilter(t *testing.T) {
+	// 23:30 UTC is already the next day in Berlin.
+	now := time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)
+	cet := time.FixedZone("CET", 60*60)
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name         string
+		filter       domain.TodoFilter
+		wantAfter    *time.Time
+		wantBefore   *time.Time
+		wantOnlyOpen bool
+	}{
+		{
+			name:   "no due filter",
+			filter: domain.TodoFilter{},
+		},
+		{
+			name:         "overdue",
+			filter:       domain.TodoFilter{Due: domain.DueOverdue},
+			wantBefore:   &now,
+			wantOnlyOpen: true,
+		},
+		{
+			name:       "today in UTC",
+			filter:     domain.TodoFilter{Due: domain.DueToday},
+			wantAfter:  at(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)),
+			wantBefore: at(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:       "today in another time zone",
+			filter:     domain.TodoFilter{Due: domain.DueToday, Location: cet},
+			wantAfter:  at(time.Date(2026, 10, 19, 0, 0, 0, 0, cet)),
+			wantBefore: at(time.Date(2026, 10, 20, 0, 0, 0, 0, cet)),
+		},
+		{
+			name:         "within days",
+			filter:       domain.TodoFilter{DueWithinDays: 7},
+			wantAfter:    &now,
+			wantBefore:   at(now.AddDate(0, 0, 7)),
+			wantOnlyOpen: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if err := resolveDueFilter(&filter, now); err != nil {
+				t.Fatal(err)
+			}
+			if !sameTime(filter.DueAfter, tt.wantAfter) || !sameTime(filter.DueBefore, tt.wantBefore) {
+				t.Errorf("range = [%v, %v), want [%v, %v)", filter.DueAfter, filter.DueBefore, tt.wantAfter, tt.wantBefore)
+			}
+			if filter.OnlyOpen != tt.wantOnlyOpen {
+				t.Errorf("OnlyOpen = %v, want %v", filter.OnlyOpen, tt.wantOnlyOpen)
+			}
+		})
+	}
+}
+
+func TestResolveDueFilterErrors(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter domain.TodoFilter
+	}{
+		{"negative days", domain.TodoFilter{DueWithinDays: -1}},
+		{"due and due_within", domain.TodoFilter{Due: domain.DueOverdue, DueWithinDays: 3}},
+		{"due_within and a range", domain.TodoFilter{DueWithinDays: 3, DueBefore: &now}},
+		{"unknown due", domain.TodoFilter{Due: "tomorrow"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if err := resolveDueFilter(&filter, now); !errors.Is(err, domain.ErrInvalidFilter) {
+				t.Errorf("resolveDueFilter() error = %v, want %v", err, domain.ErrInvalidFilter)
+			}
+		})
+	}
+}
+
+func TestValidateDueAt(t *testing.T) {
+	at := func(t time.Time) *time.Time { return &t }
+	tests := []struct {
+		name    string
+		dueAt   *time.Time
+		wantErr bool
+	}{
+		{"no due date", nil, false},
+		{"due date", at(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)), false},
+		{"zero time", &time.Time{}, true},
+		{"before 1970", at(time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC)), true},
+		{"after 9999", at(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)), true},
+	}
+	for _, tt := range tests {
+		if err := validateDueAt(tt.dueAt); tt.wantErr != errors.Is(err, domain.ErrInvalidDueDate) {
+			t.Errorf("%s: validateDueAt() error = %v, want an error: %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	return a == nil && b == nil || a != nil && b != nil && a.Equal(*b)
+}
+
+func TestValidateTodoPriority(t *testing.T) {
+	tests := []struct {
+		priority domain.Priority
+		want     domain.Priority
+		wantErr  bool
+	}{
+		{priority: "", want: domain.PriorityNone},
+		{priority: domain.PriorityUrgent, want: domain.PriorityUrgent},
+		{priority: "critical", wantErr: true},
+	}
+	for _, tt := range tests {
+		todo := &domain.Todo{Title: "todo", Priority: tt.priority}
+		err := validateTodo(todo)
+		if tt.wantErr {
+			if !errors.Is(err, domain.ErrInvalidPriority) {
+				t.Errorf("validateTodo() with priority %q error = %v, want %v", tt.priority, err, domain.ErrInvalidPriority)
+			}
+			continue
+		}
+		if err != nil || todo.Priority != tt.want {
+			t.Errorf("validateTodo() with priority %q = %q, %v, want %q", tt.priority, todo.Priority, err, tt.want)
+		}
+	}
+}
+
+func TestValidateFilterPriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  domain.TodoFilter
+		wantErr bool
+	}{
+		{"priorities", domain.TodoFilter{Priorities: []domain.Priority{domain.PriorityHigh, domain.PriorityUrgent}}, false},
+		{"minimum priority", domain.TodoFilter{MinPriority: domain.PriorityMedium}, false},
+		{"unknown priority", domain.TodoFilter{Priorities: []domain.Priority{domain.PriorityHigh, "critical"}}, true},
+		{"unknown minimum priority", domain.TodoFilter{MinPriority: "critical"}, true},
+	}
+	for _, tt := range tests {
+		if err := validateFilter(tt.filter); tt.wantErr != errors.Is(err, domain.ErrInvalidPriority) || err != nil && !tt.wantErr {
+			t.Errorf("%s: validateFilter() error = %v, want an error: %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+// treeRepo holds a hierarchy of todos by ID.
+type treeRepo struct {
+	domain.TodoRepository
+	todos   map[uint]*domain.Todo
+	heights map[uint]int
+	deleted []domain.ChildPolicy
+}
+
+func (r *treeRepo) GetByID(id uint) (*domain.Todo, error) {
+	todo, ok := r.todos[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return todo, nil
+}
+
+func (r *treeRepo) SubtreeHeight(id uint) (int, error) {
+	return r.heights[id], nil
+}
+
+func (r *treeRepo) Delete(id uint, children domain.ChildPolicy) error {
+	r.deleted = append(r.deleted, children)
+	return nil
+}
+
+func TestCheckParent(t *testing.T) {
+	id := func(id uint) *uint { return &id }
+	// 1 is the root of a chain 1 > 2 > 3 > 4 > 5 that uses all the levels
+	// allowed. 6 is a separate root with two levels of subtasks below it.
+	repo := &treeRepo{
+		todos: map[uint]*domain.Todo{
+			1: {ID: 1},
+			2: {ID: 2, ParentID: id(1)},
+			3: {ID: 3, ParentID: id(2)},
+			4: {ID: 4, ParentID: id(3)},
+			5: {ID: 5, ParentID: id(4)},
+			6: {ID: 6},
+		},
+		heights: map[uint]int{1: 4, 2: 3, 3: 2, 4: 1, 6: 2},
+	}
+	u := &todoUsecase{todoRepo: repo}
+
+	tests := []struct {
+		name    string
+		todo    domain.Todo
+		wantErr error
+	}{
+		{name: "no parent", todo: domain.Todo{}},
+		{name: "new todo at the last level", todo: domain.Todo{ParentID: id(4)}},
+		{name: "new todo below the last level", todo: domain.Todo{ParentID: id(5)}, wantErr: domain.ErrTodoTooDeep},
+		{name: "unknown parent", todo: domain.Todo{ParentID: id(9)}, wantErr: domain.ErrUnknownParent},
+		{name: "own parent", todo: domain.Todo{ID: 3, ParentID: id(3)}, wantErr: domain.ErrTodoCycle},
+		{name: "below a descendant", todo: domain.Todo{ID: 2, ParentID: id(4)}, wantErr: domain.ErrTodoCycle},
+		{name: "moved with its subtasks", todo: domain.Todo{ID: 6, ParentID: id(2)}},
+		{name: "moved with too many subtasks", todo: domain.Todo{ID: 6, ParentID: id(3)}, wantErr: domain.ErrTodoTooDeep},
+		{name: "moved to the root", todo: domain.Todo{ID: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := u.checkParent(&tt.todo); !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Errorf("checkParent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteChildPolicy(t *testing.T) {
+	tests := []struct {
+		children domain.ChildPolicy
+		want     domain.ChildPolicy
+		wantErr  error
+	}{
+		{children: "", want: domain.ChildrenReparent},
+		{children: domain.ChildrenReparent, want: domain.ChildrenReparent},
+		{children: domain.ChildrenCascade, want: domain.ChildrenCascade},
+		{children: "orphan", wantErr: domain.ErrInvalidChildren},
+	}
+	for _, tt := range tests {
+		repo := &treeRepo{}
+		u := &todoUsecase{todoRepo: repo}
+		err := u.Delete(1, tt.children)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) || len(repo.deleted) != 0 {
+				t.Errorf("Delete(%q) error = %v, deleted %v, want %v", tt.children, err, repo.deleted, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || len(repo.deleted) != 1 || repo.deleted[0] != tt.want {
+			t.Errorf("Delete(%q) error = %v, deleted with %v, want %q", tt.children, err, repo.deleted, tt.want)
+		}
+	}
+}
+
+// dependencyRepo holds todos and the todos blocking them.
+type dependencyRepo struct {
+	domain.TodoRepository
+	todos    map[uint]*domain.Todo
+	blockers map[uint][]uint
+	updates  int
+}
+
+func (r *dependencyRepo) GetByID(id uint) (*domain.Todo, error) {
+	todo, ok := r.todos[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	stored := *todo
+	return &stored, nil
+}
+
+func (r *dependencyRepo) DependsOn(id, otherID uint) (bool, error) {
+	for _, blocker := range r.blockers[id] {
+		if blocker == otherID {
+			return true, nil
+		}
+		if found, _ := r.DependsOn(blocker, otherID); found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *dependencyRepo) AddBlocker(id, blockedByID uint) error {
+	r.blockers[id] = append(r.blockers[id], blockedByID)
+	return nil
+}
+
+func (r *dependencyRepo) Update(todo *domain.Todo) error {
+	r.updates++
+	return nil
+}
+
+func TestAddBlocker(t *testing.T) {
+	tests := []struct {
+		name       string
+		dependency domain.Dependency
+		wantErr    error
+	}{
+		{name: "new blocker", dependency: domain.Dependency{TodoID: 1, BlockedByID: 4}},
+		{name: "blocked by itself", dependency: domain.Dependency{TodoID: 1, BlockedByID: 1}, wantErr: domain.ErrDependencyCycle},
+		{name: "blocked by its blocked todo", dependency: domain.Dependency{TodoID: 2, BlockedByID: 1}, wantErr: domain.ErrDependencyCycle},
+		{name: "indirect cycle", dependency: domain.Dependency{TodoID: 3, BlockedByID: 1}, wantErr: domain.ErrDependencyCycle},
+		{name: "unknown blocker", dependency: domain.Dependency{TodoID: 1, BlockedByID: 9}, wantErr: domain.ErrUnknownBlocker},
+		{name: "unknown todo", dependency: domain.Dependency{TodoID: 9, BlockedByID: 1}, wantErr: domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1 is blocked by 2, which is blocked by 3.
+			repo := &dependencyRepo{
+				todos:    map[uint]*domain.Todo{1: {ID: 1}, 2: {ID: 2}, 3: {ID: 3}, 4: {ID: 4}},
+				blockers: map[uint][]uint{1: {2}, 2: {3}},
+			}
+			u := &todoUsecase{todoRepo: repo}
+
+			err := u.AddBlocker(&tt.dependency)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("AddBlocker() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.blockers[tt.dependency.TodoID]) > 1 {
+					t.Errorf("blockers of %d = %v, want them unchanged", tt.dependency.TodoID, repo.blockers[tt.dependency.TodoID])
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found, _ := repo.DependsOn(tt.dependency.TodoID, tt.dependency.BlockedByID); !found {
+				t.Errorf("%d is not blocked by %d", tt.dependency.TodoID, tt.dependency.BlockedByID)
+			}
+		})
+	}
+}
+
+func TestUpdateBlockedTodo(t *testing.T) {
+	tests := []struct {
+		name    string
+		blocked bool
+		status  domain.Status
+		wantErr error
+	}{
+		{name: "done while blocked", blocked: true, status: domain.StatusDone, wantErr: domain.ErrTodoBlocked},
+		{name: "in progress while blocked", blocked: true, status: domain.StatusInProgress},
+		{name: "cancelled while blocked", blocked: true, status: domain.StatusCancelled},
+		{name: "done once unblocked", status: domain.StatusDone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &dependencyRepo{todos: map[uint]*domain.Todo{
+				1: {ID: 1, Status: domain.StatusOpen, Priority: domain.PriorityNone, Blocked: tt.blocked},
+			}}
+			u := &todoUsecase{todoRepo: repo, workflow: domain.DefaultWorkflow()}
+
+			err := u.Update(&domain.Todo{ID: 1, Status: tt.status})
+			if !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && repo.updates != 0 {
+				t.Errorf("todo was updated %d times, want it unchanged", repo.updates)
+			}
+		})
+	}
+}
+
+func TestValidateFilterRanges(t *testing.T) {
+	at := func(day int) *time.Time {
+		t := time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	tests := []struct {
+		name    string
+		filter  domain.TodoFilter
+		wantErr bool
+	}{
+		{name: "completed in a week", filter: domain.TodoFilter{CompletedAfter: at(12), CompletedBefore: at(19)}},
+		{name: "completed after only", filter: domain.TodoFilter{CompletedAfter: at(12)}},
+		{name: "completed before only", filter: domain.TodoFilter{CompletedBefore: at(19)}},
+		{name: "completed range reversed", filter: domain.TodoFilter{CompletedAfter: at(19), CompletedBefore: at(12)}, wantErr: true},
+		{name: "empty completed range", filter: domain.TodoFilter{CompletedAfter: at(12), CompletedBefore: at(12)}, wantErr: true},
+		{name: "created range reversed", filter: domain.TodoFilter{CreatedAfter: at(19), CreatedBefore: at(12)}, wantErr: true},
+		{name: "updated range reversed", filter: domain.TodoFilter{UpdatedAfter: at(19), UpdatedBefore: at(12)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFilter(tt.filter)
+			if tt.wantErr != (err != nil) || err != nil && !errors.Is(err, domain.ErrInvalidFilter) {
+				t.Errorf("validateFilter() error = %v, want an error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"time"
+
+	"go-todo-api/internal/domain"
+)
+
+func (u *todoUsecase) GetTrash() ([]*domain.Todo, error) {
+	return u.todoRepo.GetTrash()
+}
+
+func (u *todoUsecase) Restore(id uint) (*domain.Todo, error) {
+	if err := u.todoRepo.Restore(id); err != nil {
+		return nil, err
+	}
+	todo, err := u.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	todo.Overdue = todo.IsOverdue(time.Now())
+	return todo, nil
+}
+
+func (u *todoUsecase) Purge(id uint) error {
+	return u.todoRepo.Purge(id)
+}
+
+func (u *todoUsecase) PurgeTrash(before time.Time) (int, error) {
+	return u.todoRepo.PurgeDeletedBefore(before)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+// trashRepo holds todos and moves them to and from the trash. Like the
+// database, it no longer finds todos in the trash by ID.
+type trashRepo struct {
+	domain.TodoRepository
+	todos   map[uint]*domain.Todo
+	trashed map[uint]bool
+}
+
+func (r *trashRepo) GetByID(id uint) (*domain.Todo, error) {
+	todo, ok := r.todos[id]
+	if !ok || r.trashed[id] {
+		return nil, domain.ErrNotFound
+	}
+	stored := *todo
+	return &stored, nil
+}
+
+func (r *trashRepo) GetLinks(id uint) ([]domain.TodoLink, error) {
+	return nil, nil
+}
+
+func (r *trashRepo) Delete(id uint, children domain.ChildPolicy) error {
+	if _, err := r.GetByID(id); err != nil {
+		return err
+	}
+	r.trashed[id] = true
+	return nil
+}
+
+func (r *trashRepo) Restore(id uint) error {
+	if !r.trashed[id] {
+		return domain.ErrNotFound
+	}
+	delete(r.trashed, id)
+	return nil
+}
+
+func TestTrashedTodo(t *testing.T) {
+	repo := &trashRepo{
+		todos:   map[uint]*domain.Todo{1: {ID: 1, Title: "Plan"}, 2: {ID: 2, Title: "Ship"}},
+		trashed: make(map[uint]bool),
+	}
+	u := &todoUsecase{todoRepo: repo, workflow: domain.DefaultWorkflow()}
+	comments := NewCommentUsecase(&commentRepo{comment: domain.Comment{ID: 5, TodoID: 1}}, repo)
+
+	if err := u.Delete(1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.GetByID(1); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() of a trashed todo error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if err := u.Delete(1, ""); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Delete() of a trashed todo error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if _, err := comments.GetByID(1, 5); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() of a comment of a trashed todo error = %v, want %v", err, domain.ErrNotFound)
+	}
+	if err := u.AddBlocker(&domain.Dependency{TodoID: 2, BlockedByID: 1}); !errors.Is(err, domain.ErrUnknownBlocker) {
+		t.Errorf("AddBlocker() with a trashed blocker error = %v, want %v", err, domain.ErrUnknownBlocker)
+	}
+
+	todo, err := u.Restore(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if todo.ID != 1 || todo.Title != "Plan" {
+		t.Errorf("Restore() = %+v, want todo 1", todo)
+	}
+	if _, err := comments.GetByID(1, 5); err != nil {
+		t.Errorf("GetByID() of a comment of a restored todo error = %v", err)
+	}
+	if _, err := u.Restore(1); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Restore() of a todo not in the trash error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"fmt"
+
+	"go-todo-api/internal/domain"
+)
+
+func (u *todoUsecase) Workflow() *domain.Workflow {
+	return u.workflow
+}
+
+// resolveStatus settles the status a todo is saved with, existing being the
+// stored todo or nil for a new one, and derives Completed from it.
+//
+// Clients that only know the completed flag either leave out the status or
+// send back the one they read. If they change completed, the todo moves to
+// the done state or back to the initial state.
+func (u *todoUsecase) resolveStatus(todo, existing *domain.Todo) error {
+	if todo.Status == "" || (existing != nil && todo.Status == existing.Status) {
+		switch {
+		case existing != nil && todo.Completed == existing.Completed:
+			todo.Status = existing.Status
+		case todo.Completed:
+			todo.Status = u.workflow.Done
+		default:
+			todo.Status = u.workflow.Initial
+		}
+	}
+	if _, ok := u.workflow.State(todo.Status); !ok {
+		return domain.ErrInvalidStatus
+	}
+	if existing != nil && !u.workflow.CanTransition(existing.Status, todo.Status) {
+		return fmt.Errorf("%w: cannot move from %s to %s", domain.ErrInvalidTransition, existing.Status, todo.Status)
+	}
+	todo.Completed = u.workflow.IsClosed(todo.Status)
+	return nil
+}
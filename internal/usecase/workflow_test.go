@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"go-todo-api/internal/domain"
+)
+
+func TestResolveStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		todo          domain.Todo
+		existing      *domain.Todo
+		wantStatus    domain.Status
+		wantCompleted bool
+		wantErr       error
+	}{
+		{
+			name:       "new todo",
+			todo:       domain.Todo{},
+			wantStatus: domain.StatusOpen,
+		},
+		{
+			name:          "new completed todo",
+			todo:          domain.Todo{Completed: true},
+			wantStatus:    domain.StatusDone,
+			wantCompleted: true,
+		},
+		{
+			name:          "new todo with a closed status",
+			todo:          domain.Todo{Status: domain.StatusCancelled},
+			wantStatus:    domain.StatusCancelled,
+			wantCompleted: true,
+		},
+		{
+			name:       "completed flag contradicting the status",
+			todo:       domain.Todo{Status: domain.StatusInProgress, Completed: true},
+			wantStatus: domain.StatusInProgress,
+		},
+		{
+			name:       "status left out",
+			todo:       domain.Todo{},
+			existing:   &domain.Todo{Status: domain.StatusInReview},
+			wantStatus: domain.StatusInReview,
+		},
+		{
+			name:          "completed through the flag",
+			todo:          domain.Todo{Status: domain.StatusInProgress, Completed: true},
+			existing:      &domain.Todo{Status: domain.StatusInProgress},
+			wantStatus:    domain.StatusDone,
+			wantCompleted: true,
+		},
+		{
+			name:       "reopened through the flag",
+			todo:       domain.Todo{Status: domain.StatusCancelled},
+			existing:   &domain.Todo{Status: domain.StatusCancelled, Completed: true},
+			wantStatus: domain.StatusOpen,
+		},
+		{
+			name:          "closed status kept",
+			todo:          domain.Todo{Completed: true},
+			existing:      &domain.Todo{Status: domain.StatusCancelled, Completed: true},
+			wantStatus:    domain.StatusCancelled,
+			wantCompleted: true,
+		},
+		{
+			name:          "allowed transition",
+			todo:          domain.Todo{Status: domain.StatusDone},
+			existing:      &domain.Todo{Status: domain.StatusInReview},
+			wantStatus:    domain.StatusDone,
+			wantCompleted: true,
+		},
+		{
+			name:     "rejected transition",
+			todo:     domain.Todo{Status: domain.StatusInReview},
+			existing: &domain.Todo{Status: domain.StatusOpen},
+			wantErr:  domain.ErrInvalidTransition,
+		},
+		{
+			name:     "completed through the flag from a state that cannot move to done",
+			todo:     domain.Todo{Completed: true},
+			existing: &domain.Todo{Status: domain.StatusBlocked},
+			wantErr:  domain.ErrInvalidTransition,
+		},
+		{
+			name:    "unknown status",
+			todo:    domain.Todo{Status: "archived"},
+			wantErr: domain.ErrInvalidStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &todoUsecase{workflow: domain.DefaultWorkflow()}
+			err := u.resolveStatus(&tt.todo, tt.existing)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("resolveStatus() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.todo.Status != tt.wantStatus || tt.todo.Completed != tt.wantCompleted {
+				t.Errorf("resolveStatus() = %q, completed %v, want %q, completed %v",
+					tt.todo.Status, tt.todo.Completed, tt.wantStatus, tt.wantCompleted)
+			}
+		})
+	}
+}
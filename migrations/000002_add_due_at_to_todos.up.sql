@@ -0,0 +1,2 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS due_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_todos_due_at ON todos (due_at); 
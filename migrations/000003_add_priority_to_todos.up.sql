@@ -0,0 +1,2 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_todos_priority ON todos (priority); 
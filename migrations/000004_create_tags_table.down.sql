@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS todo_tags;
+DROP TABLE IF EXISTS tags; 
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_todos_parent_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS parent_id; 
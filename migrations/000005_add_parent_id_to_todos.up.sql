@@ -0,0 +1,2 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES todos (id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_todos_parent_id ON todos (parent_id); 
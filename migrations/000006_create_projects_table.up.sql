@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS project_id INTEGER REFERENCES projects (id);
+CREATE INDEX IF NOT EXISTS idx_todos_project_id ON todos (project_id); 
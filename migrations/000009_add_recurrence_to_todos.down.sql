@@ -0,0 +1,3 @@
+ALTER TABLE todos DROP COLUMN IF EXISTS next_occurrence_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS occurrence;
+ALTER TABLE todos DROP COLUMN IF EXISTS recurrence; 
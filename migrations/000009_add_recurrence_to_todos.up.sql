@@ -0,0 +1,3 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS recurrence VARCHAR(255);
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS occurrence INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS next_occurrence_id INTEGER REFERENCES todos (id) ON DELETE SET NULL; 
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS reminders (
+    id SERIAL PRIMARY KEY,
+    todo_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    remind_at TIMESTAMP WITH TIME ZONE,
+    offset_minutes INTEGER,
+    fire_at TIMESTAMP WITH TIME ZONE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    fired_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((remind_at IS NULL) <> (offset_minutes IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_todo_id ON reminders (todo_id);
+CREATE INDEX IF NOT EXISTS idx_reminders_status ON reminders (status);
+CREATE INDEX IF NOT EXISTS idx_reminders_fire_at ON reminders (fire_at);
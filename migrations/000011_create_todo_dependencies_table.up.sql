@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS todo_dependencies (
+    todo_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    blocked_by_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (todo_id, blocked_by_id),
+    CHECK (todo_id <> blocked_by_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_dependencies_blocked_by_id ON todo_dependencies (blocked_by_id);
@@ -0,0 +1,2 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS checklist JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS checklist_auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,20 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS position VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+
+-- Give existing todos evenly spaced five-digit base 62 positions in the
+-- order they were created.
+WITH numbered AS (
+    SELECT id, row_number() OVER (ORDER BY id) AS n, count(*) OVER () AS total FROM todos
+), spaced AS (
+    SELECT id, n * (916132832 / (total + 1)) AS v FROM numbered
+)
+UPDATE todos t
+SET position = rtrim((
+    SELECT string_agg(
+        substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (s.v / (62 ^ k)::bigint % 62)::int + 1, 1),
+        '' ORDER BY k DESC)
+    FROM generate_series(0, 4) AS k
+), '0')
+FROM spaced s
+WHERE t.id = s.id;
+
+CREATE INDEX IF NOT EXISTS idx_todos_position ON todos (position COLLATE "C", id);
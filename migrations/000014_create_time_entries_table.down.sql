@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS time_entries;
+ALTER TABLE todos DROP COLUMN IF EXISTS estimate_minutes;
@@ -0,0 +1,17 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS estimate_minutes INTEGER;
+
+CREATE TABLE IF NOT EXISTS time_entries (
+    id SERIAL PRIMARY KEY,
+    todo_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    user_name VARCHAR(100) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    manual BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_todo_id ON time_entries (todo_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_started_at ON time_entries (started_at);
+-- A user can only have one running timer.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries (user_name) WHERE ended_at IS NULL;
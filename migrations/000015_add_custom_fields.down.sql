@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_todos_custom_fields;
+ALTER TABLE todos DROP COLUMN IF EXISTS custom_fields;
+ALTER TABLE projects DROP COLUMN IF EXISTS custom_fields;
@@ -0,0 +1,5 @@
+-- Without the column, todos in the trash would reappear, so they are removed
+-- for good. Their attachment files are left on disk.
+DELETE FROM todos WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_todos_deleted_at;
+ALTER TABLE todos DROP COLUMN IF EXISTS deleted_at;
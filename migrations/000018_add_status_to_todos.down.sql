@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_todos_status;
+ALTER TABLE todos DROP COLUMN IF EXISTS status;
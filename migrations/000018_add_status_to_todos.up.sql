@@ -0,0 +1,5 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS status VARCHAR(50) NOT NULL DEFAULT 'open';
+
+UPDATE todos SET status = 'done' WHERE completed;
+
+CREATE INDEX IF NOT EXISTS idx_todos_status ON todos (status);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS todo_events;
+DROP INDEX IF EXISTS idx_todos_completed_at;
+ALTER TABLE todos DROP COLUMN IF EXISTS completed_at;
@@ -0,0 +1,20 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ;
+
+-- The time of the last change is the best guess for when existing todos
+-- were completed.
+UPDATE todos SET completed_at = updated_at WHERE completed;
+
+CREATE INDEX IF NOT EXISTS idx_todos_completed_at ON todos (completed_at);
+
+CREATE TABLE IF NOT EXISTS todo_events (
+    id SERIAL PRIMARY KEY,
+    todo_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_events_todo_id ON todo_events (todo_id);
+
+INSERT INTO todo_events (todo_id, type, status, created_at)
+SELECT id, 'completed', status, completed_at FROM todos WHERE completed;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS todo_links (
+    todo_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    linked_todo_id INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (todo_id, linked_todo_id),
+    CHECK (todo_id <> linked_todo_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_todo_links_linked_todo_id ON todo_links (linked_todo_id);
+
+-- A todo can be the duplicate of only one other todo.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_todo_links_duplicate_of ON todo_links (todo_id) WHERE type = 'duplicate_of';
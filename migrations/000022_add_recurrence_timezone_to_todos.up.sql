@@ -0,0 +1,3 @@
+-- Existing series keep being evaluated in UTC, which is what the empty
+-- time zone stands for.
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS recurrence_timezone VARCHAR(64) NOT NULL DEFAULT '';